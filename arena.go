@@ -0,0 +1,377 @@
+// arena.go
+package octypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// defaultArenaChunkSize is the chunk size used by NewDecodeArena(0).
+const defaultArenaChunkSize = 64 << 10
+
+// DecodeArena batch-allocates the strings of values decoded in bulk, such
+// as thousands of LocalizedText rows read by an import. Strings are copied
+// into large shared chunks instead of each getting its own allocation, and
+// repeated map keys are stored once, so the decoded data is a handful of
+// objects for the garbage collector rather than one per string.
+//
+// Memory is released as a unit: a chunk is freed once no string pointing
+// into it remains reachable. Reset detaches the arena from its chunks so
+// later decodes start new ones; strings handed out earlier stay valid.
+//
+// A DecodeArena is not safe for concurrent use.
+type DecodeArena struct {
+	chunkSize int
+	chunk     []byte
+	keys      map[string]string
+	chunks    int
+	bytes     int
+}
+
+// NewDecodeArena returns an arena allocating chunks of chunkSize bytes, or
+// 64KB if chunkSize is not positive.
+func NewDecodeArena(chunkSize int) *DecodeArena {
+	if chunkSize <= 0 {
+		chunkSize = defaultArenaChunkSize
+	}
+	return &DecodeArena{chunkSize: chunkSize}
+}
+
+// String returns a copy of s stored in the arena. Strings larger than a
+// quarter of the chunk size are allocated on their own so they do not
+// waste the rest of a chunk.
+func (a *DecodeArena) String(s string) string {
+	return arenaString(a, s)
+}
+
+// arenaString returns a copy of s stored in the arena, as String does.
+func arenaString[T string | []byte](a *DecodeArena, s T) string {
+	if len(s) == 0 {
+		return ""
+	}
+	if len(s) > a.chunkSize/4 {
+		return string(s)
+	}
+	if cap(a.chunk)-len(a.chunk) < len(s) {
+		a.chunk = make([]byte, 0, a.chunkSize)
+		a.chunks++
+	}
+	start := len(a.chunk)
+	a.chunk = append(a.chunk, s...)
+	a.bytes += len(s)
+	return unsafe.String(&a.chunk[start], len(s))
+}
+
+// key returns the arena copy of the map key k, storing each distinct key
+// once.
+func (a *DecodeArena) key(k string) string {
+	return arenaKey(a, k)
+}
+
+// arenaKey returns the arena copy of the map key k, as key does. Looking
+// up a []byte key does not allocate.
+func arenaKey[T string | []byte](a *DecodeArena, k T) string {
+	if s, ok := a.keys[string(k)]; ok {
+		return s
+	}
+	if a.keys == nil {
+		a.keys = make(map[string]string)
+	}
+	s := arenaString(a, k)
+	a.keys[s] = s
+	return s
+}
+
+// LocalizedText returns a copy of lt whose keys and values are stored in
+// the arena.
+func (a *DecodeArena) LocalizedText(lt LocalizedText) LocalizedText {
+	if lt == nil {
+		return nil
+	}
+	out := make(LocalizedText, len(lt))
+	for k, v := range lt {
+		out[a.key(k)] = a.String(v)
+	}
+	return out
+}
+
+// IntDictionary returns a copy of id whose keys are stored in the arena.
+func (a *DecodeArena) IntDictionary(id IntDictionary) IntDictionary {
+	if id == nil {
+		return nil
+	}
+	out := make(IntDictionary, len(id))
+	for k, v := range id {
+		out[a.key(k)] = v
+	}
+	return out
+}
+
+var (
+	localizedTextsType  = reflect.TypeOf([]LocalizedText(nil))
+	intDictionariesType = reflect.TypeOf([]IntDictionary(nil))
+)
+
+// DecodeLocalizedTexts decodes a JSON array of LocalizedText objects,
+// applying the same normalization as LocalizedText.UnmarshalJSON. Keys and
+// values are copied from data straight into the arena, which takes the
+// place of interning.
+func (a *DecodeArena) DecodeLocalizedTexts(data []byte) ([]LocalizedText, error) {
+	d, err := newArenaDecoder(a, data, localizedTextsType)
+	if err != nil {
+		return nil, err
+	}
+	if d.null() {
+		return nil, nil
+	}
+	texts := []LocalizedText{}
+	err = d.array(func() error {
+		if d.null() {
+			texts = append(texts, nil)
+			return nil
+		}
+		lt := make(LocalizedText, jsonObjectLen(d.data[d.i:]))
+		err := d.object(localizedTextsType.Elem(), func(key string) error {
+			if d.null() {
+				lt[key] = ""
+				return nil
+			}
+			if d.data[d.i] != '"' {
+				return d.typeError(localizedTextsType.Elem().Elem())
+			}
+			lt[key] = d.string(false)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if lt, err = lt.normalized(); err != nil {
+			return err
+		}
+		texts = append(texts, lt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return texts, nil
+}
+
+// DecodeIntDictionaries decodes a JSON array of IntDictionary objects.
+// Keys are copied from data straight into the arena, which takes the place
+// of interning.
+func (a *DecodeArena) DecodeIntDictionaries(data []byte) ([]IntDictionary, error) {
+	d, err := newArenaDecoder(a, data, intDictionariesType)
+	if err != nil {
+		return nil, err
+	}
+	if d.null() {
+		return nil, nil
+	}
+	dicts := []IntDictionary{}
+	err = d.array(func() error {
+		if d.null() {
+			dicts = append(dicts, nil)
+			return nil
+		}
+		id := make(IntDictionary, jsonObjectLen(d.data[d.i:]))
+		err := d.object(intDictionariesType.Elem(), func(key string) error {
+			if d.null() {
+				id[key] = 0
+				return nil
+			}
+			n, err := d.int()
+			if err != nil {
+				return err
+			}
+			id[key] = n
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		dicts = append(dicts, id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dicts, nil
+}
+
+// arenaDecoder reads a JSON document already checked with json.Valid,
+// so it only has to report values of the wrong type.
+type arenaDecoder struct {
+	a    *DecodeArena
+	data []byte
+	i    int
+	// t is the type being decoded, for error messages.
+	t reflect.Type
+}
+
+// newArenaDecoder returns a decoder of data into a value of type t, or the
+// syntax error json.Unmarshal reports for it.
+func newArenaDecoder(a *DecodeArena, data []byte, t reflect.Type) (*arenaDecoder, error) {
+	if !json.Valid(data) {
+		// Unmarshal checks the syntax before decoding anything.
+		return nil, json.Unmarshal(data, reflect.New(t).Interface())
+	}
+	d := &arenaDecoder{a: a, data: data, t: t}
+	d.skipSpace()
+	return d, nil
+}
+
+func (d *arenaDecoder) skipSpace() {
+	for d.i < len(d.data) && isJSONSpace(d.data[d.i]) {
+		d.i++
+	}
+}
+
+// null consumes a null literal at the current position, if there is one.
+func (d *arenaDecoder) null() bool {
+	if d.data[d.i] != 'n' {
+		return false
+	}
+	d.i += len("null")
+	d.skipSpace()
+	return true
+}
+
+// array calls elem for each element of the array at the current position,
+// with the position on the element.
+func (d *arenaDecoder) array(elem func() error) error {
+	if d.data[d.i] != '[' {
+		return d.typeError(d.t)
+	}
+	d.i++
+	d.skipSpace()
+	for d.data[d.i] != ']' {
+		if err := elem(); err != nil {
+			return err
+		}
+		if d.data[d.i] == ',' {
+			d.i++
+			d.skipSpace()
+		}
+	}
+	d.i++
+	return nil
+}
+
+// object calls field for each member of the object of type t at the
+// current position, with the position on the member value.
+func (d *arenaDecoder) object(t reflect.Type, field func(key string) error) error {
+	if d.data[d.i] != '{' {
+		return d.typeError(t)
+	}
+	d.i++
+	d.skipSpace()
+	for d.data[d.i] != '}' {
+		key := d.string(true)
+		d.i++ // ':'
+		d.skipSpace()
+		if err := field(key); err != nil {
+			return err
+		}
+		if d.data[d.i] == ',' {
+			d.i++
+			d.skipSpace()
+		}
+	}
+	d.i++
+	d.skipSpace()
+	return nil
+}
+
+// string reads the string at the current position into the arena, as a
+// map key if key is set. Strings without escapes are copied as they are;
+// others, and invalid UTF-8, which json.Unmarshal replaces, are decoded by
+// json.Unmarshal first.
+func (d *arenaDecoder) string(key bool) string {
+	start := d.i
+	escaped := false
+	d.i++
+	for d.data[d.i] != '"' {
+		if d.data[d.i] == '\\' {
+			escaped = true
+			d.i++
+		}
+		d.i++
+	}
+	d.i++
+	token := d.data[start:d.i]
+	d.skipSpace()
+	if raw := token[1 : len(token)-1]; !escaped && utf8.Valid(raw) {
+		if key {
+			return arenaKey(d.a, raw)
+		}
+		return arenaString(d.a, raw)
+	}
+	var s string
+	json.Unmarshal(token, &s)
+	if key {
+		return arenaKey(d.a, s)
+	}
+	return arenaString(d.a, s)
+}
+
+// int reads the integer at the current position.
+func (d *arenaDecoder) int() (int, error) {
+	start := d.i
+	for d.i < len(d.data) && strings.IndexByte("+-.0123456789eE", d.data[d.i]) >= 0 {
+		d.i++
+	}
+	if d.i == start {
+		return 0, d.typeError(reflect.TypeOf(0))
+	}
+	n, err := strconv.ParseInt(string(d.data[start:d.i]), 10, 0)
+	if err != nil {
+		return 0, &json.UnmarshalTypeError{Value: "number " + string(d.data[start:d.i]), Type: reflect.TypeOf(0), Offset: int64(d.i)}
+	}
+	d.skipSpace()
+	return int(n), nil
+}
+
+// typeError reports the value at the current position as not decodable
+// into t.
+func (d *arenaDecoder) typeError(t reflect.Type) error {
+	var value string
+	switch d.data[d.i] {
+	case '{':
+		value = "object"
+	case '[':
+		value = "array"
+	case '"':
+		value = "string"
+	case 't', 'f':
+		value = "bool"
+	default:
+		value = "number"
+	}
+	return &json.UnmarshalTypeError{Value: value, Type: t, Offset: int64(d.i)}
+}
+
+// Chunks returns the number of chunks allocated since the last Reset.
+func (a *DecodeArena) Chunks() int {
+	return a.chunks
+}
+
+// Bytes returns the number of string bytes stored in chunks since the last
+// Reset.
+func (a *DecodeArena) Bytes() int {
+	return a.bytes
+}
+
+// Reset detaches the arena from its chunks and key table. Strings already
+// returned remain valid; their memory is reclaimed by the garbage collector
+// once they are unreachable.
+func (a *DecodeArena) Reset() {
+	a.chunk = nil
+	a.keys = nil
+	a.chunks = 0
+	a.bytes = 0
+}
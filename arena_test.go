@@ -0,0 +1,165 @@
+// arena_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestDecodeArenaString(t *testing.T) {
+	a := NewDecodeArena(64)
+	s1 := a.String(string([]byte("hello")))
+	s2 := a.String(string([]byte("world")))
+	if s1 != "hello" || s2 != "world" {
+		t.Errorf("Expected hello and world, got %q and %q", s1, s2)
+	}
+	if uintptr(unsafe.Pointer(unsafe.StringData(s2)))-uintptr(unsafe.Pointer(unsafe.StringData(s1))) != 5 {
+		t.Errorf("Expected strings to share a chunk")
+	}
+	if a.Chunks() != 1 || a.Bytes() != 10 {
+		t.Errorf("Expected 1 chunk and 10 bytes, got %d and %d", a.Chunks(), a.Bytes())
+	}
+	long := strings.Repeat("x", 17)
+	if got := a.String(long); got != long || a.Chunks() != 1 {
+		t.Errorf("Expected long string to bypass the chunk, got %q with %d chunks", got, a.Chunks())
+	}
+
+	a.Reset()
+	if a.Chunks() != 0 || s1 != "hello" {
+		t.Errorf("Expected reset arena with valid strings, got %d chunks and %q", a.Chunks(), s1)
+	}
+}
+
+func TestDecodeArenaLocalizedTexts(t *testing.T) {
+	a := NewDecodeArena(0)
+	texts, err := a.DecodeLocalizedTexts([]byte(`[{"en":"Hello","fr":"Bonjour"},{"en":"Bye"},null]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(texts) != 3 || texts[0]["fr"] != "Bonjour" || texts[1]["en"] != "Bye" || texts[2] != nil {
+		t.Errorf("Unexpected texts %v", texts)
+	}
+	var k0, k1 string
+	for k := range texts[0] {
+		if k == "en" {
+			k0 = k
+		}
+	}
+	for k := range texts[1] {
+		k1 = k
+	}
+	if unsafe.StringData(k0) != unsafe.StringData(k1) {
+		t.Errorf("Expected repeated keys to be stored once")
+	}
+
+	dicts, err := a.DecodeIntDictionaries([]byte(`[{"a":1},{"a":2}]`))
+	if err != nil || len(dicts) != 2 || dicts[1]["a"] != 2 {
+		t.Errorf("Unexpected dictionaries %v, %v", dicts, err)
+	}
+	if _, err := a.DecodeLocalizedTexts([]byte(`{`)); err == nil {
+		t.Errorf("Expected error for invalid JSON")
+	}
+}
+
+func TestDecodeArenaMatchesUnmarshal(t *testing.T) {
+	texts := []string{
+		`[]`,
+		`null`,
+		` [ { "en" : "Hello" , "fr":"Bonjour" } , null , {} ] `,
+		`[{"en":"Tab\tquote\" \u00e9\ud83d\ude00","fr":null,"de":"Grüße"}]`,
+		"[{\"en\":\"bad \xff utf8\"}]",
+		`[{"en":"a","en":"b"}]`,
+		`[{"en":5}]`,
+		`[5]`,
+		`{}`,
+		`[{"en":"x"}`,
+	}
+	for _, in := range texts {
+		var want []LocalizedText
+		wantErr := json.Unmarshal([]byte(in), &want)
+		got, err := NewDecodeArena(0).DecodeLocalizedTexts([]byte(in))
+		if (err != nil) != (wantErr != nil) || err == nil && !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Expected %v, %v, got %v, %v", in, want, wantErr, got, err)
+		}
+	}
+
+	dicts := []string{
+		`[{"a":1,"b":-2,"c":null},null,{}]`,
+		`[{"a\u0062":3}]`,
+		`[{"a":1.5}]`,
+		`[{"a":1e3}]`,
+		`[{"a":"1"}]`,
+		`[{"a":99999999999999999999}]`,
+		`[{"a":}]`,
+	}
+	for _, in := range dicts {
+		var want []IntDictionary
+		wantErr := json.Unmarshal([]byte(in), &want)
+		got, err := NewDecodeArena(0).DecodeIntDictionaries([]byte(in))
+		if (err != nil) != (wantErr != nil) || err == nil && !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Expected %v, %v, got %v, %v", in, want, wantErr, got, err)
+		}
+	}
+}
+
+func TestDecodeArenaNormalizes(t *testing.T) {
+	SetLocalizedTextSanitizer(func(lang, value string) string { return strings.ToUpper(value) })
+	defer SetLocalizedTextSanitizer(nil)
+	texts, err := NewDecodeArena(0).DecodeLocalizedTexts([]byte(`[{"en":"hi"}]`))
+	if err != nil || texts[0]["en"] != "HI" {
+		t.Errorf("Expected sanitized HI, got %v, %v", texts, err)
+	}
+}
+
+func TestDecodeArenaAllocations(t *testing.T) {
+	data := benchmarkTextsJSON(1000)
+	plain := testing.AllocsPerRun(10, func() {
+		var texts []LocalizedText
+		json.Unmarshal(data, &texts)
+	})
+	arena := testing.AllocsPerRun(10, func() {
+		NewDecodeArena(0).DecodeLocalizedTexts(data)
+	})
+	if arena >= plain/2 {
+		t.Errorf("Expected the arena to allocate less than half of json.Unmarshal's %.0f allocations, got %.0f", plain, arena)
+	}
+}
+
+func benchmarkTextsJSON(n int) []byte {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(`{"en":"Product ` + strconv.Itoa(i) + `","fr":"Produit ` + strconv.Itoa(i) + `"}`)
+	}
+	sb.WriteByte(']')
+	return []byte(sb.String())
+}
+
+func BenchmarkUnmarshalLocalizedTexts(b *testing.B) {
+	data := benchmarkTextsJSON(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var texts []LocalizedText
+		if err := json.Unmarshal(data, &texts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeArenaLocalizedTexts(b *testing.B) {
+	data := benchmarkTextsJSON(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a := NewDecodeArena(0)
+		if _, err := a.DecodeLocalizedTexts(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
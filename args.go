@@ -0,0 +1,93 @@
+// args.go
+package octypes
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Columns returns the `db` tag names of the struct type of v, which may be
+// a struct or a pointer to one, in declaration order. Args and NamedArgs
+// return values in the same order.
+func Columns(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := dbFields(t)
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.name
+	}
+	return columns
+}
+
+// Args returns the values of the `db` tagged fields of v, a struct or a
+// pointer to one, for use as query arguments. Fields are returned as is,
+// except that the `ocnull` policy of a field, as described for Normalize,
+// is applied to a copy of its value; v is never modified. octypes fields
+// are turned into driver values by their Value method when the query runs.
+func Args(v interface{}) ([]interface{}, error) {
+	sv, err := structValueOf(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := dbFields(sv.Type())
+	args := make([]interface{}, len(fields))
+	for i, f := range fields {
+		if args[i], err = f.arg(sv); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// NamedArgs is like Args but returns sql.NamedArg values named after the
+// `db` tags, for drivers supporting named parameters.
+func NamedArgs(v interface{}) ([]sql.NamedArg, error) {
+	sv, err := structValueOf(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := dbFields(sv.Type())
+	args := make([]sql.NamedArg, len(fields))
+	for i, f := range fields {
+		arg, err := f.arg(sv)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = sql.Named(f.name, arg)
+	}
+	return args, nil
+}
+
+// arg returns the value of f in the struct sv, with its null policy
+// applied to a copy.
+func (f dbField) arg(sv reflect.Value) (interface{}, error) {
+	fv := sv.FieldByIndex(f.index)
+	if f.nullPolicy == "" {
+		return fv.Interface(), nil
+	}
+	cp := reflect.New(fv.Type()).Elem()
+	cp.Set(fv)
+	if err := normalizeField(cp, f.nullPolicy); err != nil {
+		return nil, fmt.Errorf("column %q: %w", f.name, err)
+	}
+	return cp.Interface(), nil
+}
+
+// structValueOf returns the struct v holds or points to.
+func structValueOf(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("cannot build arguments from %T: not a struct", v)
+	}
+	return rv, nil
+}
@@ -0,0 +1,103 @@
+// args_test.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+type argsRow struct {
+	scanRowBase
+	Name   NullString `db:"name"`
+	Email  string     `db:"email"`
+	Hidden string     `db:"-"`
+	Note   string
+}
+
+func TestColumns(t *testing.T) {
+	got := Columns(argsRow{})
+	want := []string{"id", "name", "email"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected columns %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected column %d to be %s, got %s", i, want[i], got[i])
+		}
+	}
+	if Columns(42) != nil {
+		t.Errorf("Expected nil columns for non-struct")
+	}
+}
+
+func TestArgs(t *testing.T) {
+	row := argsRow{Name: *NewNullString("Alice"), Email: "a@example.com", Hidden: "x"}
+	args, err := Args(&row)
+	if err != nil {
+		t.Fatalf("Error building args: %v", err)
+	}
+	if len(args) != 3 {
+		t.Fatalf("Expected 3 args, got %d", len(args))
+	}
+
+	id, ok := args[0].(NullInt64)
+	if !ok || id.Valid {
+		t.Errorf("Expected null NullInt64 as first arg, got %#v", args[0])
+	}
+	val, err := args[1].(driver.Valuer).Value()
+	if err != nil || val != "Alice" {
+		t.Errorf("Expected Value 'Alice', got %v and %v", val, err)
+	}
+	if args[2] != "a@example.com" {
+		t.Errorf("Expected 'a@example.com', got %v", args[2])
+	}
+
+	if _, err := Args("nope"); err == nil {
+		t.Errorf("Expected error for non-struct, got nil")
+	}
+}
+
+func TestNamedArgs(t *testing.T) {
+	args, err := NamedArgs(argsRow{Email: "b@example.com"})
+	if err != nil {
+		t.Fatalf("Error building named args: %v", err)
+	}
+	if len(args) != 3 || args[2].Name != "email" || args[2].Value != "b@example.com" {
+		t.Errorf("Unexpected named args %+v", args)
+	}
+}
+
+type argsPolicyRow struct {
+	Bio      NullString `db:"bio" ocnull:"emptyisnull"`
+	Nickname NullString `db:"nickname" ocnull:"nullisempty"`
+	Plain    NullString `db:"plain"`
+}
+
+func TestArgsNullPolicy(t *testing.T) {
+	row := argsPolicyRow{
+		Bio:   *NewNullStringAllowEmpty(""),
+		Plain: *NewNullStringAllowEmpty(""),
+	}
+	args, err := Args(&row)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args[0].(NullString).Valid || !args[1].(NullString).Valid || !args[2].(NullString).Valid {
+		t.Errorf("Expected bio null, nickname and plain valid, got %v", args)
+	}
+	if !row.Bio.Valid || row.Nickname.Valid {
+		t.Errorf("Expected the struct to be unchanged, got %+v", row)
+	}
+
+	named, err := NamedArgs(row)
+	if err != nil || named[0].Value.(NullString).Valid {
+		t.Errorf("Expected null bio named argument, got %v and %v", named, err)
+	}
+
+	var bad struct {
+		S NullString `db:"s" ocnull:"bogus"`
+	}
+	if _, err := Args(bad); err == nil {
+		t.Errorf("Expected error for unknown policy")
+	}
+}
@@ -0,0 +1,164 @@
+//go:build benchguard
+
+// benchguard_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+// The benchmark guard compares the core operations against the baseline
+// in testdata/benchguard.json and fails on allocation regressions:
+//
+//	go test -tags benchguard -run TestBenchGuard .
+//
+// Allocations do not depend on the machine, so the committed baseline
+// holds on any of them. Timings do, so they are only logged, unless a
+// threshold is given to compare them on the machine that recorded the
+// baseline. Record it after intended changes with:
+//
+//	go test -tags benchguard -run TestBenchGuard . -args -benchguard.update
+var (
+	benchGuardUpdate    = flag.Bool("benchguard.update", false, "record the benchmark baseline")
+	benchGuardThreshold = flag.Float64("benchguard.threshold", 0, "allowed ns/op increase over the baseline, as a fraction; 0 disables the check")
+)
+
+const benchGuardBaseline = "testdata/benchguard.json"
+
+// benchGuardResult is the baseline of one operation.
+type benchGuardResult struct {
+	NsPerOp     int64 `json:"ns_op"`
+	AllocsPerOp int64 `json:"allocs_op"`
+}
+
+// benchGuardOps are the guarded operations.
+var benchGuardOps = map[string]func(b *testing.B){
+	"NullString.MarshalJSON": func(b *testing.B) {
+		ns := *NewNullString("hello world")
+		for i := 0; i < b.N; i++ {
+			ns.MarshalJSON()
+		}
+	},
+	"NullString.UnmarshalJSON": func(b *testing.B) {
+		data := []byte(`"hello world"`)
+		var ns NullString
+		for i := 0; i < b.N; i++ {
+			ns.UnmarshalJSON(data)
+		}
+	},
+	"NullInt64.MarshalJSON": func(b *testing.B) {
+		ni := *NewNullInt64(1234567)
+		for i := 0; i < b.N; i++ {
+			ni.MarshalJSON()
+		}
+	},
+	"NullInt64.UnmarshalJSON": func(b *testing.B) {
+		data := []byte(`1234567`)
+		var ni NullInt64
+		for i := 0; i < b.N; i++ {
+			ni.UnmarshalJSON(data)
+		}
+	},
+	"NullInt64.Scan": func(b *testing.B) {
+		data := []byte(`1234567`)
+		var ni NullInt64
+		for i := 0; i < b.N; i++ {
+			ni.Scan(data)
+		}
+	},
+	"NullFloat64.MarshalJSON": func(b *testing.B) {
+		nf := *NewNullFloat64(3.14159)
+		for i := 0; i < b.N; i++ {
+			nf.MarshalJSON()
+		}
+	},
+	"CustomTime.MarshalJSON": func(b *testing.B) {
+		ct := *NewCustomTime(time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC))
+		for i := 0; i < b.N; i++ {
+			ct.MarshalJSON()
+		}
+	},
+	"CustomTime.UnmarshalJSON": func(b *testing.B) {
+		data := []byte(`"2023-06-15T10:20:30.123456789Z"`)
+		var ct CustomTime
+		for i := 0; i < b.N; i++ {
+			ct.UnmarshalJSON(data)
+		}
+	},
+	"LocalizedText.UnmarshalJSON": func(b *testing.B) {
+		data := []byte(`{"en":"Hello","fr":"Bonjour","de":"Hallo","es":"Hola"}`)
+		var lt LocalizedText
+		for i := 0; i < b.N; i++ {
+			lt.UnmarshalJSON(data)
+		}
+	},
+	"IntDictionary.MarshalJSON": func(b *testing.B) {
+		id := IntDictionary{"en": 1, "fr": 2, "de": 3, "es": 40}
+		for i := 0; i < b.N; i++ {
+			id.MarshalJSON()
+		}
+	},
+	"FormatHstore": func(b *testing.B) {
+		lt := LocalizedText{"en": "Hello", "fr": "Bonjour", "de": "Hallo"}
+		for i := 0; i < b.N; i++ {
+			FormatHstore(lt)
+		}
+	},
+}
+
+func TestBenchGuard(t *testing.T) {
+	names := make([]string, 0, len(benchGuardOps))
+	for name := range benchGuardOps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make(map[string]benchGuardResult, len(names))
+	for _, name := range names {
+		r := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			benchGuardOps[name](b)
+		})
+		results[name] = benchGuardResult{NsPerOp: r.NsPerOp(), AllocsPerOp: r.AllocsPerOp()}
+	}
+
+	if *benchGuardUpdate {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(benchGuardBaseline, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(benchGuardBaseline)
+	if err != nil {
+		t.Skipf("No baseline, record one with -args -benchguard.update: %v", err)
+	}
+	var baseline map[string]benchGuardResult
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		base, ok := baseline[name]
+		if !ok {
+			t.Logf("%s: no baseline", name)
+			continue
+		}
+		got := results[name]
+		if got.AllocsPerOp > base.AllocsPerOp {
+			t.Errorf("%s: Expected at most %d allocs/op, got %d", name, base.AllocsPerOp, got.AllocsPerOp)
+		}
+		if limit := float64(base.NsPerOp) * (1 + *benchGuardThreshold); *benchGuardThreshold > 0 && float64(got.NsPerOp) > limit {
+			t.Errorf("%s: Expected at most %.0f ns/op (baseline %d), got %d", name, limit, base.NsPerOp, got.NsPerOp)
+		}
+		t.Logf("%s: %d ns/op (baseline %d), %d allocs/op (baseline %d)", name, got.NsPerOp, base.NsPerOp, got.AllocsPerOp, base.AllocsPerOp)
+	}
+}
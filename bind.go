@@ -0,0 +1,167 @@
+// bind.go
+package octypes
+
+import (
+	"database/sql"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindError reports a parameter that could not be bound to its field.
+type BindError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("invalid parameter %s=%q: %v", e.Param, e.Value, e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// BindQuery sets the fields of the struct dst points to from the query
+// parameters named by their `query` tags, for filter and search endpoints:
+//
+//	type ProductFilter struct {
+//		Search   NullString      `query:"q" ocnull:"emptyisnull"`
+//		MinPrice NullFloat64     `query:"min_price"`
+//		Since    CustomTime      `query:"since"`
+//		Tags     NullStringArray `query:"tag"`
+//	}
+//
+// A missing parameter makes its field null. A parameter present but empty
+// gives a valid empty NullString, or null for other octypes types; an
+// `ocnull` tag, as described for Normalize, changes that policy per field.
+// Values are parsed by the field's Scan method, so CustomTime accepts the
+// layouts registered with RegisterTimeLayout. Array fields collect
+// repeated parameters. Plain string, integer, float, boolean and []string
+// fields are supported too, and untagged struct fields are bound
+// recursively. Malformed values fail with a *BindError.
+func BindQuery(values url.Values, dst interface{}) error {
+	rv, err := structPointerValue(dst)
+	if err != nil {
+		return err
+	}
+	return bindStruct(rv, "query", binder{values: values})
+}
+
+// binder looks up the parameters bound to struct fields.
+type binder struct {
+	values url.Values
+	// files holds uploaded files, for BindForm.
+	files       map[string][]*multipart.FileHeader
+	maxFileSize int64
+}
+
+// bindStruct binds the fields of the addressable struct rv tagged with tag.
+func bindStruct(rv reflect.Value, tag string, b binder) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		param := f.Tag.Get(tag)
+		if param == "" || param == "-" {
+			if param == "" && fv.Kind() == reflect.Struct && !isScanner(fv.Type()) {
+				if err := bindStruct(fv, tag, b); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if fhs := b.files[param]; len(fhs) > 0 {
+			if err := b.bindFile(fv, fhs[0]); err != nil {
+				return &BindError{Param: param, Value: fhs[0].Filename, Err: err}
+			}
+			continue
+		}
+		vals := b.values[param]
+		if len(vals) == 0 {
+			fv.SetZero()
+			continue
+		}
+		if err := bindField(fv, vals); err != nil {
+			return &BindError{Param: param, Value: strings.Join(vals, ","), Err: err}
+		}
+		if policy := f.Tag.Get("ocnull"); policy != "" {
+			if err := normalizeField(fv, policy); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// bindField sets fv from the parameter values vals.
+func bindField(fv reflect.Value, vals []string) error {
+	switch p := fv.Addr().Interface().(type) {
+	case *NullStringArray:
+		a := make(NullStringArray, len(vals))
+		for i, v := range vals {
+			a[i] = NullString{sql.NullString{String: v, Valid: true}}
+		}
+		*p = a
+		return nil
+	case *NullInt64Array:
+		a := make(NullInt64Array, len(vals))
+		for i, v := range vals {
+			if err := bindScanner(&a[i], v); err != nil {
+				return err
+			}
+		}
+		*p = a
+		return nil
+	case *[]string:
+		*p = append([]string(nil), vals...)
+		return nil
+	case sql.Scanner:
+		return bindScanner(p, vals[0])
+	}
+	v := strings.TrimSpace(vals[0])
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(vals[0])
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(v, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// bindScanner scans v into s. Empty values give a valid empty NullString
+// and null for other types.
+func bindScanner(s sql.Scanner, v string) error {
+	if r, ok := s.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+	if _, isString := s.(*NullString); !isString && strings.TrimSpace(v) == "" {
+		return nil
+	}
+	return s.Scan(v)
+}
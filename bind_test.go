@@ -0,0 +1,69 @@
+// bind_test.go
+package octypes
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type bindFilter struct {
+	Search   NullString      `query:"q" ocnull:"emptyisnull"`
+	Name     NullString      `query:"name"`
+	MinPrice NullFloat64     `query:"min_price"`
+	Limit    NullInt64       `query:"limit"`
+	Since    CustomTime      `query:"since"`
+	Tags     NullStringArray `query:"tag"`
+	IDs      NullInt64Array  `query:"id"`
+	Page     int             `query:"page"`
+	InStock  bool            `query:"in_stock"`
+	Sort     []string        `query:"sort"`
+	Nested   struct {
+		Lang NullString `query:"lang"`
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	values, _ := url.ParseQuery("q=&name=&min_price=9.5&since=2024-03-01&tag=a&tag=b&id=1&id=2&page=3&in_stock=true&sort=price&sort=-name&lang=fr")
+	f := bindFilter{Limit: *NewNullInt64(10)}
+	if err := BindQuery(values, &f); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f.Search.Valid {
+		t.Errorf("Expected empty q to be null, got %#v", f.Search)
+	}
+	if !f.Name.Valid || f.Name.String != "" {
+		t.Errorf("Expected empty name to be a valid empty string, got %#v", f.Name)
+	}
+	if f.MinPrice.Float64 != 9.5 {
+		t.Errorf("Expected 9.5, got %#v", f.MinPrice)
+	}
+	if f.Limit.Valid {
+		t.Errorf("Expected missing limit to be null, got %#v", f.Limit)
+	}
+	if !f.Since.Time.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-03-01, got %#v", f.Since)
+	}
+	if len(f.Tags) != 2 || f.Tags[1].String != "b" || len(f.IDs) != 2 || f.IDs[1].Int64 != 2 {
+		t.Errorf("Expected repeated parameters, got %#v and %#v", f.Tags, f.IDs)
+	}
+	if f.Page != 3 || !f.InStock || len(f.Sort) != 2 || f.Nested.Lang.String != "fr" {
+		t.Errorf("Unexpected plain fields %+v", f)
+	}
+}
+
+func TestBindQueryErrors(t *testing.T) {
+	var f bindFilter
+	err := BindQuery(url.Values{"limit": {"ten"}}, &f)
+	var be *BindError
+	if !errors.As(err, &be) || be.Param != "limit" || be.Value != "ten" {
+		t.Errorf("Expected BindError for limit, got %v", err)
+	}
+	if err := BindQuery(url.Values{"page": {"x"}}, &f); err == nil {
+		t.Errorf("Expected error for malformed page")
+	}
+	if err := BindQuery(url.Values{}, f); err == nil {
+		t.Errorf("Expected error for non-pointer")
+	}
+}
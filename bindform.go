@@ -0,0 +1,91 @@
+// bindform.go
+package octypes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// ErrFileTooLarge is wrapped by the *BindError BindForm returns for
+// uploaded files larger than FormOptions.MaxFileSize.
+var ErrFileTooLarge = errors.New("file too large")
+
+// FormOptions configures BindForm. Zero fields use the defaults documented
+// on each field.
+type FormOptions struct {
+	// MaxMemory is the number of bytes of a multipart body kept in memory,
+	// the rest being stored in temporary files, as for
+	// http.Request.ParseMultipartForm. It defaults to 32MB.
+	MaxMemory int64
+	// MaxFileSize is the largest file accepted into a NullBytes field. It
+	// defaults to 10MB.
+	MaxFileSize int64
+}
+
+// BindForm is BindQuery for the body of an application/x-www-form-urlencoded
+// or multipart/form-data request, binding the fields tagged `form`:
+//
+//	type Signup struct {
+//		Name   NullString `form:"name"`
+//		Born   CustomTime `form:"born"`
+//		Avatar NullBytes  `form:"avatar"`
+//	}
+//
+// In multipart requests, a NullBytes field is read from the file part of
+// the same name when there is one; files larger than MaxFileSize fail with
+// ErrFileTooLarge. Query string parameters are ignored; use BindQuery for
+// them.
+func BindForm(r *http.Request, dst interface{}, opts FormOptions) error {
+	rv, err := structPointerValue(dst)
+	if err != nil {
+		return err
+	}
+	if opts.MaxMemory <= 0 {
+		opts.MaxMemory = 32 << 20
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = 10 << 20
+	}
+	b := binder{maxFileSize: opts.MaxFileSize}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(opts.MaxMemory); err != nil {
+			return err
+		}
+		b.files = r.MultipartForm.File
+	} else if err := r.ParseForm(); err != nil {
+		return err
+	}
+	b.values = r.PostForm
+	return bindStruct(rv, "form", b)
+}
+
+// bindFile reads the uploaded file fh into fv, which must be a NullBytes.
+func (b binder) bindFile(fv reflect.Value, fh *multipart.FileHeader) error {
+	nb, ok := fv.Addr().Interface().(*NullBytes)
+	if !ok {
+		return fmt.Errorf("cannot bind a file to %s", fv.Type())
+	}
+	if fh.Size > b.maxFileSize {
+		return fmt.Errorf("%w: %d bytes, limit %d", ErrFileTooLarge, fh.Size, b.maxFileSize)
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, b.maxFileSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > b.maxFileSize {
+		return fmt.Errorf("%w: limit %d", ErrFileTooLarge, b.maxFileSize)
+	}
+	nb.Bytes, nb.Valid = data, true
+	return nil
+}
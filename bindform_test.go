@@ -0,0 +1,79 @@
+// bindform_test.go
+package octypes
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type bindSignup struct {
+	Name   NullString `form:"name"`
+	Born   CustomTime `form:"born"`
+	Age    NullInt64  `form:"age"`
+	Avatar NullBytes  `form:"avatar"`
+}
+
+func TestBindFormURLEncoded(t *testing.T) {
+	defer func(layouts []string) { extraTimeLayouts = layouts }(extraTimeLayouts)
+	RegisterTimeLayout("02/01/2006")
+
+	body := url.Values{"name": {"Ann"}, "born": {"24/12/1990"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/signup?age=30", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var s bindSignup
+	if err := BindForm(r, &s, FormOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Name.String != "Ann" || !s.Born.Time.Equal(time.Date(1990, 12, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected form %#v", s)
+	}
+	if s.Age.Valid {
+		t.Errorf("Expected query parameters to be ignored, got %#v", s.Age)
+	}
+}
+
+func multipartRequest(t *testing.T, fields map[string]string, file []byte) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		w.WriteField(k, v)
+	}
+	if file != nil {
+		fw, err := w.CreateFormFile("avatar", "avatar.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(file)
+	}
+	w.Close()
+	r := httptest.NewRequest(http.MethodPost, "/signup", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestBindFormMultipart(t *testing.T) {
+	r := multipartRequest(t, map[string]string{"name": "Bob", "age": "41"}, []byte("PNGDATA"))
+	var s bindSignup
+	if err := BindForm(r, &s, FormOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Name.String != "Bob" || s.Age.Int64 != 41 || string(s.Avatar.Bytes) != "PNGDATA" || !s.Avatar.Valid {
+		t.Errorf("Unexpected form %#v", s)
+	}
+
+	r = multipartRequest(t, nil, bytes.Repeat([]byte("x"), 100))
+	err := BindForm(r, &s, FormOptions{MaxFileSize: 10})
+	var be *BindError
+	if !errors.As(err, &be) || be.Param != "avatar" || !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("Expected ErrFileTooLarge for avatar, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// bufferpool.go
+package octypes
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+)
+
+// bufferTiers are the capacities of the BufferPool size classes.
+var bufferTiers = [...]int{256, 4 << 10, 64 << 10, 1 << 20}
+
+// BufferPool recycles byte slices in size classes of 256B, 4KB, 64KB and
+// 1MB. Slices are passed by pointer so that Put does not allocate. Each
+// class is a sync.Pool, so idle buffers are released by the garbage
+// collector and the pool needs no capacity tuning. It is safe for
+// concurrent use.
+type BufferPool struct {
+	tiers [len(bufferTiers)]sync.Pool
+
+	gets   atomic.Uint64
+	puts   atomic.Uint64
+	allocs atomic.Uint64
+	drops  atomic.Uint64
+}
+
+// BufferStats are the counters of a BufferPool.
+type BufferStats struct {
+	// Gets counts calls to Get.
+	Gets uint64 `json:"gets"`
+	// Puts counts buffers returned to the pool.
+	Puts uint64 `json:"puts"`
+	// Allocs counts buffers Get had to allocate.
+	Allocs uint64 `json:"allocs"`
+	// Drops counts buffers Put discarded for being too small or too large.
+	Drops uint64 `json:"drops"`
+}
+
+// NewBufferPool returns an empty BufferPool.
+func NewBufferPool() *BufferPool {
+	return &BufferPool{}
+}
+
+// Get returns an empty slice with a capacity of at least size. Sizes above
+// the largest class are allocated exactly and not pooled.
+func (p *BufferPool) Get(size int) *[]byte {
+	p.gets.Add(1)
+	for i, tier := range bufferTiers {
+		if size > tier {
+			continue
+		}
+		if b, ok := p.tiers[i].Get().(*[]byte); ok {
+			*b = (*b)[:0]
+			return b
+		}
+		p.allocs.Add(1)
+		b := make([]byte, 0, tier)
+		return &b
+	}
+	p.allocs.Add(1)
+	b := make([]byte, 0, size)
+	return &b
+}
+
+// Put returns b to the size class matching its capacity. b must not be
+// used afterwards.
+func (p *BufferPool) Put(b *[]byte) {
+	c := cap(*b)
+	if c < bufferTiers[0] || c > 2*bufferTiers[len(bufferTiers)-1] {
+		p.drops.Add(1)
+		return
+	}
+	i := len(bufferTiers) - 1
+	for c < bufferTiers[i] {
+		i--
+	}
+	p.puts.Add(1)
+	p.tiers[i].Put(b)
+}
+
+// Stats returns a snapshot of the pool's counters.
+func (p *BufferPool) Stats() BufferStats {
+	return BufferStats{
+		Gets:   p.gets.Load(),
+		Puts:   p.puts.Load(),
+		Allocs: p.allocs.Load(),
+		Drops:  p.drops.Load(),
+	}
+}
+
+// String returns Stats as JSON, so that the pool implements expvar.Var
+// and can be published with expvar.Publish.
+func (p *BufferPool) String() string {
+	b, _ := json.Marshal(p.Stats())
+	return string(b)
+}
+
+// defaultBufferPool backs the text formatting of Value and Format helpers.
+var defaultBufferPool = NewBufferPool()
+
+// DefaultBufferPool returns the package-level BufferPool used when
+// formatting hstore, array and composite values.
+func DefaultBufferPool() *BufferPool {
+	return defaultBufferPool
+}
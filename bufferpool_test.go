@@ -0,0 +1,79 @@
+// bufferpool_test.go
+package octypes
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestBufferPool(t *testing.T) {
+	p := NewBufferPool()
+	tests := []struct {
+		size, cap int
+	}{
+		{0, 256},
+		{256, 256},
+		{257, 4 << 10},
+		{100 << 10, 1 << 20},
+		{3 << 20, 3 << 20},
+	}
+	for _, tt := range tests {
+		b := p.Get(tt.size)
+		if len(*b) != 0 || cap(*b) != tt.cap {
+			t.Errorf("Expected len 0 and cap %d for size %d, got %d and %d", tt.cap, tt.size, len(*b), cap(*b))
+		}
+	}
+
+	b := p.Get(10)
+	*b = append(*b, "hello"...)
+	p.Put(b)
+	small := make([]byte, 0, 16)
+	p.Put(&small)
+
+	if got := p.Get(10); len(*got) != 0 || cap(*got) < 256 {
+		t.Errorf("Expected a reset pooled buffer, got len %d cap %d", len(*got), cap(*got))
+	}
+	st := p.Stats()
+	if st.Gets != 7 || st.Puts != 1 || st.Drops != 1 || st.Allocs < 6 {
+		t.Errorf("Unexpected stats %+v", st)
+	}
+}
+
+func benchmarkHstore() LocalizedText {
+	lt := make(LocalizedText, 30)
+	for i := 0; i < 30; i++ {
+		lt["lang"+strconv.Itoa(i)] = "A translated sentence with \"quotes\" number " + strconv.Itoa(i)
+	}
+	return lt
+}
+
+func BenchmarkFormatHstore(b *testing.B) {
+	lt := benchmarkHstore()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FormatHstore(lt)
+	}
+}
+
+func BenchmarkFormatArray(b *testing.B) {
+	elems := make([]NullString, 100)
+	for i := range elems {
+		elems[i] = *NewNullString("element " + strconv.Itoa(i))
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FormatArray(elems)
+	}
+}
+
+func BenchmarkBufferPool(b *testing.B) {
+	p := NewBufferPool()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := p.Get(1024)
+			*buf = append(*buf, "payload"...)
+			p.Put(buf)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+// builder.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNoColumns is returned by Mapper.Update when no field is left to set.
+var ErrNoColumns = errors.New("no columns to set")
+
+// Placeholder formats the n-th (1-based) bind parameter of a query.
+type Placeholder func(n int) string
+
+// PlaceholderDollar formats parameters as $1, $2, ... (Postgres).
+func PlaceholderDollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// PlaceholderQuestion formats every parameter as ? (MySQL, SQLite).
+func PlaceholderQuestion(int) string {
+	return "?"
+}
+
+// Mapper builds simple SELECT, INSERT and UPDATE statements from structs
+// whose columns are declared with `db` tags. Table and column names are
+// written as is and must come from trusted code, never from user input.
+// The zero value uses PlaceholderDollar.
+type Mapper struct {
+	Placeholder Placeholder
+}
+
+func (m Mapper) placeholder(n int) string {
+	if m.Placeholder == nil {
+		return PlaceholderDollar(n)
+	}
+	return m.Placeholder(n)
+}
+
+// Select returns a SELECT of the columns of v from table, suitable for
+// scanning with ScanRow. where, if not empty, is appended as the WHERE
+// clause.
+func (m Mapper) Select(table string, v interface{}, where string) string {
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(Columns(v), ", "))
+	b.WriteString(" FROM ")
+	b.WriteString(table)
+	if where != "" {
+		b.WriteString(" WHERE ")
+		b.WriteString(where)
+	}
+	return b.String()
+}
+
+// Insert returns an INSERT of every column of v into table and its
+// arguments.
+func (m Mapper) Insert(table string, v interface{}) (string, []interface{}, error) {
+	columns := Columns(v)
+	args, err := Args(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(table)
+	b.WriteString(" (")
+	b.WriteString(strings.Join(columns, ", "))
+	b.WriteString(") VALUES (")
+	for i := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(m.placeholder(i + 1))
+	}
+	b.WriteString(")")
+	return b.String(), args, nil
+}
+
+// Update returns an UPDATE of table identified by the key columns of v and
+// its arguments. Null fields, those whose Value is nil, are treated as not
+// provided and left out of the SET clause, so partially filled structs
+// update only what they carry. Nullness is judged after `ocnull` policies,
+// so an empty string in an "emptyisnull" field is not set either. Key
+// columns are never set and must not be null. ErrNoColumns is returned
+// when nothing is left to set.
+func (m Mapper) Update(table string, v interface{}, keys ...string) (string, []interface{}, error) {
+	if len(keys) == 0 {
+		return "", nil, errors.New("update requires at least one key column")
+	}
+	columns := Columns(v)
+	values, err := Args(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		b    strings.Builder
+		args []interface{}
+	)
+	b.WriteString("UPDATE ")
+	b.WriteString(table)
+	b.WriteString(" SET ")
+	for i, column := range columns {
+		if slices.Contains(keys, column) {
+			continue
+		}
+		null, err := isNullArg(values[i])
+		if err != nil {
+			return "", nil, fmt.Errorf("column %q: %w", column, err)
+		}
+		if null {
+			continue
+		}
+		if len(args) > 0 {
+			b.WriteString(", ")
+		}
+		args = append(args, values[i])
+		b.WriteString(column)
+		b.WriteString(" = ")
+		b.WriteString(m.placeholder(len(args)))
+	}
+	if len(args) == 0 {
+		return "", nil, ErrNoColumns
+	}
+
+	b.WriteString(" WHERE ")
+	for i, key := range keys {
+		j := slices.Index(columns, key)
+		if j < 0 {
+			return "", nil, fmt.Errorf("no field with db tag %q", key)
+		}
+		if null, _ := isNullArg(values[j]); null {
+			return "", nil, fmt.Errorf("key column %q is null", key)
+		}
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+		args = append(args, values[j])
+		b.WriteString(key)
+		b.WriteString(" = ")
+		b.WriteString(m.placeholder(len(args)))
+	}
+	return b.String(), args, nil
+}
+
+// isNullArg reports whether a field value is SQL NULL.
+func isNullArg(v interface{}) (bool, error) {
+	if v == nil {
+		return true, nil
+	}
+	// Check nil pointers first: calling a value Value method through a nil
+	// pointer panics.
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return true, nil
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		return dv == nil, err
+	}
+	return false, nil
+}
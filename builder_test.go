@@ -0,0 +1,99 @@
+// builder_test.go
+package octypes
+
+import (
+	"errors"
+	"testing"
+)
+
+type builderUser struct {
+	ID    NullInt64     `db:"id"`
+	Name  NullString    `db:"name"`
+	Email string        `db:"email"`
+	Bio   LocalizedText `db:"bio"`
+}
+
+func TestMapperSelect(t *testing.T) {
+	var m Mapper
+	got := m.Select("users", builderUser{}, "id = $1")
+	want := "SELECT id, name, email, bio FROM users WHERE id = $1"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got := m.Select("users", &builderUser{}, ""); got != "SELECT id, name, email, bio FROM users" {
+		t.Errorf("Unexpected SELECT without WHERE: %q", got)
+	}
+}
+
+func TestMapperInsert(t *testing.T) {
+	m := Mapper{Placeholder: PlaceholderQuestion}
+	query, args, err := m.Insert("users", builderUser{Email: "a@example.com"})
+	if err != nil {
+		t.Fatalf("Error building INSERT: %v", err)
+	}
+	want := "INSERT INTO users (id, name, email, bio) VALUES (?, ?, ?, ?)"
+	if query != want {
+		t.Errorf("Expected %q, got %q", want, query)
+	}
+	if len(args) != 4 || args[2] != "a@example.com" {
+		t.Errorf("Unexpected args %v", args)
+	}
+}
+
+func TestMapperUpdateSkipsNull(t *testing.T) {
+	var m Mapper
+	u := builderUser{ID: *NewNullInt64(7), Name: *NewNullString("Bob"), Email: "b@example.com"}
+	query, args, err := m.Update("users", &u, "id")
+	if err != nil {
+		t.Fatalf("Error building UPDATE: %v", err)
+	}
+	want := "UPDATE users SET name = $1, email = $2 WHERE id = $3"
+	if query != want {
+		t.Errorf("Expected %q, got %q", want, query)
+	}
+	if len(args) != 3 || args[1] != "b@example.com" {
+		t.Errorf("Unexpected args %v", args)
+	}
+	if id, ok := args[2].(NullInt64); !ok || id.Int64 != 7 {
+		t.Errorf("Expected key argument 7, got %v", args[2])
+	}
+}
+
+func TestMapperUpdateSkipsNilPointer(t *testing.T) {
+	type patch struct {
+		ID   NullInt64   `db:"id"`
+		Name *NullString `db:"name"`
+		Bio  *NullString `db:"bio"`
+	}
+	var m Mapper
+	query, args, err := m.Update("users", patch{ID: *NewNullInt64(7), Bio: NewNullString("Hi")}, "id")
+	if err != nil {
+		t.Fatalf("Error building UPDATE: %v", err)
+	}
+	if want := "UPDATE users SET bio = $1 WHERE id = $2"; query != want {
+		t.Errorf("Expected %q, got %q", want, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("Unexpected args %v", args)
+	}
+}
+
+func TestMapperUpdateErrors(t *testing.T) {
+	var m Mapper
+	type keyOnly struct {
+		ID   NullInt64  `db:"id"`
+		Name NullString `db:"name"`
+	}
+	if _, _, err := m.Update("t", keyOnly{ID: *NewNullInt64(1)}, "id"); !errors.Is(err, ErrNoColumns) {
+		t.Errorf("Expected ErrNoColumns, got %v", err)
+	}
+	if _, _, err := m.Update("t", keyOnly{Name: *NewNullString("x")}, "id"); err == nil {
+		t.Errorf("Expected error for null key, got nil")
+	}
+	if _, _, err := m.Update("t", keyOnly{Name: *NewNullString("x")}, "missing"); err == nil {
+		t.Errorf("Expected error for unknown key, got nil")
+	}
+	if _, _, err := m.Update("t", keyOnly{Name: *NewNullString("x")}); err == nil {
+		t.Errorf("Expected error without key columns, got nil")
+	}
+}
@@ -0,0 +1,95 @@
+// clone.go
+package octypes
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+)
+
+// Clone returns a copy of ns.
+func (ns NullString) Clone() NullString {
+	return ns
+}
+
+// Clone returns a copy of ni.
+func (ni NullInt64) Clone() NullInt64 {
+	return ni
+}
+
+// Clone returns a copy of nb.
+func (nb NullBool) Clone() NullBool {
+	return nb
+}
+
+// Clone returns a copy of nf.
+func (nf NullFloat64) Clone() NullFloat64 {
+	return nf
+}
+
+// Clone returns a copy of ct.
+func (ct CustomTime) Clone() CustomTime {
+	return ct
+}
+
+// Clone returns a copy of nb that does not share the byte slice.
+func (nb NullBytes) Clone() NullBytes {
+	nb.Bytes = bytes.Clone(nb.Bytes)
+	return nb
+}
+
+// Clone returns a shallow copy of c: fields of T that are maps, slices or
+// pointers are shared with c.
+func (c Composite[T]) Clone() Composite[T] {
+	return c
+}
+
+// Clone returns a copy of lt that does not share the token not parsed yet.
+func (lt LazyTime) Clone() LazyTime {
+	lt.raw = bytes.Clone(lt.raw)
+	return lt
+}
+
+// Clone returns a copy of lt that does not share map storage. A nil lt
+// gives nil.
+func (lt LocalizedText) Clone() LocalizedText {
+	return maps.Clone(lt)
+}
+
+// Clone returns a copy of lh that does not share map storage.
+func (lh LocalizedTextHstore) Clone() LocalizedTextHstore {
+	return maps.Clone(lh)
+}
+
+// Clone returns a copy of id that does not share map storage.
+func (id IntDictionary) Clone() IntDictionary {
+	return maps.Clone(id)
+}
+
+// Clone returns a copy of pt that shares neither the outer nor the inner
+// maps.
+func (pt PluralizedText) Clone() PluralizedText {
+	if pt == nil {
+		return nil
+	}
+	out := make(PluralizedText, len(pt))
+	for lang, forms := range pt {
+		out[lang] = maps.Clone(forms)
+	}
+	return out
+}
+
+// Clone returns a copy of lc that does not share map storage.
+func (lc LocalizedContent) Clone() LocalizedContent {
+	return maps.Clone(lc)
+}
+
+// Clone returns a copy of a that does not share its backing array.
+func (a NullStringArray) Clone() NullStringArray {
+	return slices.Clone(a)
+}
+
+// Clone returns a copy of a that does not share its backing array.
+func (a NullInt64Array) Clone() NullInt64Array {
+	return slices.Clone(a)
+}
@@ -0,0 +1,48 @@
+// clone_test.go
+package octypes
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	lt := LocalizedText{"en": "a"}
+	c := lt.Clone()
+	c["en"] = "b"
+	if lt["en"] != "a" {
+		t.Errorf("Expected original LocalizedText to be unchanged, got %v", lt)
+	}
+	if LocalizedText(nil).Clone() != nil {
+		t.Errorf("Expected nil clone of nil LocalizedText")
+	}
+
+	id := IntDictionary{"a": 1}
+	idc := id.Clone()
+	idc["a"] = 2
+	if id["a"] != 1 {
+		t.Errorf("Expected original IntDictionary to be unchanged, got %v", id)
+	}
+
+	pt := PluralizedText{"en": {PluralOne: "item"}}
+	ptc := pt.Clone()
+	ptc["en"][PluralOne] = "thing"
+	if pt["en"][PluralOne] != "item" {
+		t.Errorf("Expected original PluralizedText to be unchanged, got %v", pt)
+	}
+
+	nb := *NewNullBytes([]byte("abc"))
+	nbc := nb.Clone()
+	nbc.Bytes[0] = 'x'
+	if string(nb.Bytes) != "abc" || !nbc.Valid {
+		t.Errorf("Expected original NullBytes to be unchanged, got %q", nb.Bytes)
+	}
+
+	arr := NullStringArray{*NewNullString("a")}
+	arrc := arr.Clone()
+	arrc[0] = NullString{}
+	if !arr[0].Valid {
+		t.Errorf("Expected original NullStringArray to be unchanged, got %v", arr)
+	}
+
+	if ns := NewNullString("x").Clone(); !ns.Equal(*NewNullString("x")) {
+		t.Errorf("Expected clone equal to original, got %v", ns)
+	}
+}
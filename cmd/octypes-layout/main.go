@@ -0,0 +1,177 @@
+// Command octypes-layout reports padding waste in structs with octypes
+// fields and suggests field orders that remove it.
+//
+// Usage:
+//
+//	octypes-layout [-all] [-min bytes] [dir ...]
+//
+// Each directory, the current one by default, is type-checked as a Go
+// package, with its files selected by the build constraints of the current
+// platform and its dependencies loaded from export data built by the go
+// command. Structs declared at package level that have an octypes field,
+// or every struct with -all, are reported when reordering their fields
+// saves at least -min bytes per value.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/coffyg/octypes"
+)
+
+const octypesPath = "github.com/coffyg/octypes"
+
+func main() {
+	all := flag.Bool("all", false, "report structs without octypes fields too")
+	minSavings := flag.Int64("min", 1, "only report structs saving at least this many bytes")
+	flag.Parse()
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	failed := false
+	for _, dir := range dirs {
+		if err := run(os.Stdout, dir, *all, *minSavings); err != nil {
+			fmt.Fprintln(os.Stderr, "octypes-layout:", err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run writes the reports of the package in dir to w.
+func run(w io.Writer, dir string, all bool, minSavings int64) error {
+	reports, err := analyzeDir(dir, all)
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Savings() >= minSavings {
+			fmt.Fprintln(w, r)
+		}
+	}
+	return nil
+}
+
+// analyzeDir type-checks the non-test Go files of dir that match the
+// build constraints of the current platform, and returns the layout
+// reports of its package-level structs, sorted by name.
+func analyzeDir(dir string, all bool) ([]octypes.LayoutReport, error) {
+	bp, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(bp.GoFiles))
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	exports, err := exportData(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Dependencies are loaded from their compiled export data, which is
+	// much faster than type-checking them from source.
+	imp := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(file)
+	})
+	sizes := types.SizesFor("gc", runtime.GOARCH)
+	conf := types.Config{Importer: imp, Sizes: sizes}
+	pkg, err := conf.Check(bp.Name, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+	var reports []octypes.LayoutReport
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		st, ok := tn.Type().Underlying().(*types.Struct)
+		if !ok || (!all && !hasOctypesField(st)) {
+			continue
+		}
+		reports = append(reports, analyzeStruct(pkg.Name()+"."+name, st, sizes, types.RelativeTo(pkg)))
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+	return reports, nil
+}
+
+// exportData returns the export data files of the dependencies of the
+// package in dir by import path, as built by the go command.
+func exportData(dir string) (map[string]string, error) {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-f", "{{.ImportPath}} {{.Export}}", ".")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w", err)
+	}
+	exports := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, file, ok := strings.Cut(line, " "); ok && file != "" {
+			exports[path] = file
+		}
+	}
+	return exports, nil
+}
+
+// analyzeStruct reports the layout of st with the given sizes. Field types
+// are printed relative to the package qualify leaves unqualified.
+func analyzeStruct(name string, st *types.Struct, sizes types.Sizes, relative types.Qualifier) octypes.LayoutReport {
+	qualify := func(p *types.Package) string {
+		if q := relative(p); q == "" {
+			return ""
+		}
+		return p.Name()
+	}
+	fields := make([]octypes.LayoutField, st.NumFields())
+	for i := range fields {
+		f := st.Field(i)
+		fields[i] = octypes.LayoutField{
+			Name:  f.Name(),
+			Type:  types.TypeString(f.Type(), qualify),
+			Size:  sizes.Sizeof(f.Type()),
+			Align: sizes.Alignof(f.Type()),
+		}
+	}
+	return octypes.AnalyzeFields(name, fields)
+}
+
+// hasOctypesField reports whether a field of st has a type declared in
+// the octypes package.
+func hasOctypesField(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if named, ok := st.Field(i).Type().(*types.Named); ok {
+			if pkg := named.Obj().Pkg(); pkg != nil && pkg.Path() == octypesPath {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// main_test.go
+package main
+
+import (
+	"go/token"
+	"go/types"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDir(t *testing.T) {
+	reports, err := analyzeDir("testdata/sample", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 || reports[0].Name != "sample.Product" || reports[1].Name != "sample.Tight" {
+		t.Fatalf("Expected the Product and Tight reports, got %v", reports)
+	}
+	// Product is bool, NullInt64, bool, NullString; Tight is its fields
+	// without the bools.
+	sizes := types.SizesFor("gc", runtime.GOARCH)
+	field := func(name string, t types.Type) *types.Var { return types.NewField(token.NoPos, nil, name, t, false) }
+	nullInt64 := types.NewStruct([]*types.Var{field("Int64", types.Typ[types.Int64]), field("Valid", types.Typ[types.Bool])}, nil)
+	nullString := types.NewStruct([]*types.Var{field("String", types.Typ[types.String]), field("Valid", types.Typ[types.Bool])}, nil)
+	product := types.NewStruct([]*types.Var{
+		field("Active", types.Typ[types.Bool]), field("ID", nullInt64),
+		field("Deleted", types.Typ[types.Bool]), field("Name", nullString),
+	}, nil)
+	suggested := types.NewStruct([]*types.Var{
+		field("ID", nullInt64), field("Name", nullString),
+		field("Active", types.Typ[types.Bool]), field("Deleted", types.Typ[types.Bool]),
+	}, nil)
+	if want, wantSuggested := sizes.Sizeof(product), sizes.Sizeof(suggested); reports[0].Size != want || reports[0].SuggestedSize != wantSuggested {
+		t.Errorf("Expected Product to shrink from %d to %d bytes, got %d and %d", want, wantSuggested, reports[0].Size, reports[0].SuggestedSize)
+	}
+	if reports[0].Fields[1].Type != "octypes.NullInt64" {
+		t.Errorf("Expected a package-qualified type, got %s", reports[0].Fields[1].Type)
+	}
+
+	all, err := analyzeDir("testdata/sample", true)
+	if err != nil || len(all) != 3 {
+		t.Errorf("Expected 3 reports with -all, got %d, %v", len(all), err)
+	}
+
+	var out strings.Builder
+	if err := run(&out, "testdata/sample", false, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "sample.Product") || strings.Contains(out.String(), "sample.Tight") {
+		t.Errorf("Expected only Product to be reported, got %s", out.String())
+	}
+}
@@ -0,0 +1,7 @@
+//go:build octypes_layout_excluded
+
+package sample
+
+// Product redeclares the type of sample.go, which only type-checks if
+// build constraints are ignored.
+type Product struct{}
@@ -0,0 +1,21 @@
+package sample
+
+import "github.com/coffyg/octypes"
+
+type Product struct {
+	Active  bool
+	ID      octypes.NullInt64
+	Deleted bool
+	Name    octypes.NullString
+}
+
+type Tight struct {
+	ID   octypes.NullInt64
+	Name octypes.NullString
+}
+
+type Plain struct {
+	A bool
+	B int64
+	C bool
+}
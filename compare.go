@@ -0,0 +1,81 @@
+// compare.go
+package octypes
+
+import (
+	"cmp"
+	"strings"
+)
+
+// compareNulls orders null values after valid ones, as Postgres does for
+// ascending ORDER BY. ok is false if both values are valid and the caller
+// must compare them.
+func compareNulls(aValid, bValid bool) (c int, ok bool) {
+	switch {
+	case aValid && bValid:
+		return 0, false
+	case aValid:
+		return -1, true
+	case bValid:
+		return 1, true
+	}
+	return 0, true
+}
+
+// Compare returns -1, 0 or +1 depending on whether ns sorts before, with or
+// after other. Strings compare bytewise and null sorts after every string,
+// so the result can be passed to slices.SortFunc.
+func (ns NullString) Compare(other NullString) int {
+	if c, ok := compareNulls(ns.Valid, other.Valid); ok {
+		return c
+	}
+	return strings.Compare(ns.String, other.String)
+}
+
+// Less reports whether ns sorts before other, for use with sort.Slice.
+func (ns NullString) Less(other NullString) bool {
+	return ns.Compare(other) < 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether ni sorts before, with or
+// after other. Null sorts after every integer.
+func (ni NullInt64) Compare(other NullInt64) int {
+	if c, ok := compareNulls(ni.Valid, other.Valid); ok {
+		return c
+	}
+	return cmp.Compare(ni.Int64, other.Int64)
+}
+
+// Less reports whether ni sorts before other, for use with sort.Slice.
+func (ni NullInt64) Less(other NullInt64) bool {
+	return ni.Compare(other) < 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether nf sorts before, with or
+// after other. As with cmp.Compare, NaN sorts before every other float, and
+// null sorts after every float.
+func (nf NullFloat64) Compare(other NullFloat64) int {
+	if c, ok := compareNulls(nf.Valid, other.Valid); ok {
+		return c
+	}
+	return cmp.Compare(nf.Float64, other.Float64)
+}
+
+// Less reports whether nf sorts before other, for use with sort.Slice.
+func (nf NullFloat64) Less(other NullFloat64) bool {
+	return nf.Compare(other) < 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether ct is before, equal to
+// or after other, comparing instants regardless of location. Null sorts
+// after every time.
+func (ct CustomTime) Compare(other CustomTime) int {
+	if c, ok := compareNulls(ct.Valid, other.Valid); ok {
+		return c
+	}
+	return ct.Time.Compare(other.Time)
+}
+
+// Less reports whether ct sorts before other, for use with sort.Slice.
+func (ct CustomTime) Less(other CustomTime) bool {
+	return ct.Compare(other) < 0
+}
@@ -0,0 +1,48 @@
+// compare_test.go
+package octypes
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"NullString", NewNullString("a").Compare(*NewNullString("b")), -1},
+		{"NullString null last", NullString{}.Compare(*NewNullString("z")), 1},
+		{"NullString nulls", NullString{}.Compare(NullString{}), 0},
+		{"NullInt64", NewNullInt64(2).Compare(*NewNullInt64(2)), 0},
+		{"NullInt64 null last", NewNullInt64(math.MaxInt64).Compare(NullInt64{}), -1},
+		{"NullFloat64 NaN first", NewNullFloat64(math.NaN()).Compare(*NewNullFloat64(math.Inf(-1))), -1},
+		{"NullFloat64", NewNullFloat64(1.5).Compare(*NewNullFloat64(0.5)), 1},
+		{"CustomTime", NewCustomTime(time.Unix(1, 0)).Compare(*NewCustomTime(time.Unix(2, 0))), -1},
+		{"CustomTime null last", NewCustomTimeNull().Compare(*NewCustomTime(time.Unix(2, 0))), 1},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: Expected %d, got %d", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestSortNullTypes(t *testing.T) {
+	ints := []NullInt64{*NewNullInt64(3), {}, *NewNullInt64(1), *NewNullInt64(2)}
+	sort.Slice(ints, func(i, j int) bool { return ints[i].Less(ints[j]) })
+	want := []NullInt64{*NewNullInt64(1), *NewNullInt64(2), *NewNullInt64(3), {}}
+	if !slices.Equal(ints, want) {
+		t.Errorf("Expected %v, got %v", want, ints)
+	}
+
+	strs := []NullString{{}, *NewNullString("b"), *NewNullString("a")}
+	slices.SortFunc(strs, NullString.Compare)
+	if strs[0].String != "a" || strs[1].String != "b" || strs[2].Valid {
+		t.Errorf("Expected a, b, null, got %v", strs)
+	}
+}
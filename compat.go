@@ -0,0 +1,24 @@
+// compat.go
+package octypes
+
+// Layouts for SetTimeValueLayout.
+const (
+	// TimeLayoutSQLServer is accepted by SQL Server for datetime2 and
+	// datetimeoffset parameters, keeping the offset and 100ns precision.
+	TimeLayoutSQLServer = "2006-01-02T15:04:05.9999999-07:00"
+	// TimeLayoutOracle matches the TO_TIMESTAMP_TZ format
+	// 'YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM'.
+	TimeLayoutOracle = "2006-01-02 15:04:05.000000000 -07:00"
+)
+
+// BoolValueMode selects the driver.Value representation of NullBool.
+type BoolValueMode uint8
+
+const (
+	// BoolValueNative returns a bool. This is the default.
+	BoolValueNative BoolValueMode = iota
+	// BoolValueInt returns int64 0 or 1, for databases without a native
+	// boolean type such as Oracle before 23c or SQL Server bit columns
+	// reached through drivers that reject bool.
+	BoolValueInt
+)
@@ -0,0 +1,66 @@
+// compat_test.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestSetTimeValueLayout(t *testing.T) {
+	defer SetTimeValueLayout("")
+	ct := NewCustomTime(time.Date(2024, 2, 3, 4, 5, 6, 700000000, time.FixedZone("", 3600)))
+
+	SetTimeValueLayout(TimeLayoutSQLServer)
+	val, err := ct.Value()
+	if err != nil {
+		t.Errorf("Error getting Value from CustomTime: %v", err)
+	}
+	if val != "2024-02-03T04:05:06.7+01:00" {
+		t.Errorf("Expected '2024-02-03T04:05:06.7+01:00', got '%v'", val)
+	}
+
+	SetTimeValueLayout(TimeLayoutOracle)
+	val, _ = ct.Value()
+	if val != "2024-02-03 04:05:06.700000000 +01:00" {
+		t.Errorf("Expected '2024-02-03 04:05:06.700000000 +01:00', got '%v'", val)
+	}
+
+	if val, _ := NewCustomTimeNull().Value(); val != nil {
+		t.Errorf("Expected nil Value for null CustomTime, got %v", val)
+	}
+
+	SetTimeValueLayout("")
+	if _, ok := mustValue(t, ct).(time.Time); !ok {
+		t.Errorf("Expected time.Time Value after resetting layout")
+	}
+}
+
+func TestSetBoolValueMode(t *testing.T) {
+	defer SetBoolValueMode(BoolValueNative)
+
+	SetBoolValueMode(BoolValueInt)
+	if val := mustValue(t, NewNullBool(true)); val != int64(1) {
+		t.Errorf("Expected Value 1, got %v", val)
+	}
+	if val := mustValue(t, NewNullBool(false)); val != int64(0) {
+		t.Errorf("Expected Value 0, got %v", val)
+	}
+	if val := mustValue(t, NewNullBoolFromString("")); val != nil {
+		t.Errorf("Expected nil Value, got %v", val)
+	}
+
+	SetBoolValueMode(BoolValueNative)
+	if val := mustValue(t, NewNullBool(true)); val != true {
+		t.Errorf("Expected Value true, got %v", val)
+	}
+}
+
+func mustValue(t *testing.T, v driver.Valuer) driver.Value {
+	t.Helper()
+	val, err := v.Value()
+	if err != nil {
+		t.Fatalf("Error getting Value: %v", err)
+	}
+	return val
+}
@@ -0,0 +1,243 @@
+// composite.go
+package octypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseComposite parses the text form of a Postgres composite (row) value
+// such as (1,"a b",,"say ""hi"""). Empty unquoted fields are NULL.
+func ParseComposite(src string) ([]NullString, error) {
+	s := strings.TrimSpace(src)
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("invalid composite literal %q", src)
+	}
+	s = s[1 : len(s)-1]
+
+	var (
+		fields []NullString
+		buf    strings.Builder
+	)
+	for i := 0; ; {
+		buf.Reset()
+		quoted := false
+		for i < len(s) && s[i] != ',' {
+			c := s[i]
+			i++
+			switch {
+			case c == '"':
+				quoted = true
+				closed := false
+				for i < len(s) {
+					c = s[i]
+					i++
+					if c == '\\' && i < len(s) {
+						buf.WriteByte(s[i])
+						i++
+						continue
+					}
+					if c == '"' {
+						// A doubled quote is a literal quote.
+						if i < len(s) && s[i] == '"' {
+							buf.WriteByte('"')
+							i++
+							continue
+						}
+						closed = true
+						break
+					}
+					buf.WriteByte(c)
+				}
+				if !closed {
+					return nil, fmt.Errorf("invalid composite literal %q: unterminated quote", src)
+				}
+			case c == '\\' && i < len(s):
+				buf.WriteByte(s[i])
+				i++
+				quoted = true
+			default:
+				buf.WriteByte(c)
+			}
+		}
+
+		if buf.Len() == 0 && !quoted {
+			fields = append(fields, NullString{})
+		} else {
+			fields = append(fields, *NewNullStringAllowEmpty(buf.String()))
+		}
+		if i == len(s) {
+			return fields, nil
+		}
+		i++ // skip ','
+	}
+}
+
+// FormatComposite formats fields as a Postgres composite literal. Invalid
+// fields are written as NULL.
+func FormatComposite(fields []NullString) string {
+	b := defaultBufferPool.Get(0)
+	buf := append(*b, '(')
+	for i, f := range fields {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if f.Valid {
+			buf = appendCompositeField(buf, f.String)
+		}
+	}
+	buf = append(buf, ')')
+	s := string(buf)
+	*b = buf
+	defaultBufferPool.Put(b)
+	return s
+}
+
+func appendCompositeField(buf []byte, s string) []byte {
+	if s != "" && !strings.ContainsAny(s, "(),\"\\ \t\n\r\v\f") {
+		return append(buf, s...)
+	}
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf = append(buf, s[i])
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '"')
+}
+
+// Composite is a nullable Postgres composite value mapped onto the struct
+// T. The `db` tagged fields of T correspond, in declaration order, to the
+// attributes of the composite type. Each field must be a sql.Scanner (such
+// as the octypes types) or a string.
+type Composite[T any] struct {
+	V     T
+	Valid bool
+}
+
+// NewComposite creates a valid Composite.
+func NewComposite[T any](v T) *Composite[T] {
+	return &Composite[T]{V: v, Valid: true}
+}
+
+// isEmpty reports whether c holds the zero T.
+func (c *Composite[T]) isEmpty() bool {
+	return reflect.ValueOf(&c.V).Elem().IsZero()
+}
+
+// setEmpty sets c to the valid zero T.
+func (c *Composite[T]) setEmpty() {
+	var zero T
+	c.V, c.Valid = zero, true
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *Composite[T]) Scan(value interface{}) error {
+	if value == nil {
+		var zero T
+		c.V, c.Valid = zero, false
+		return nil
+	}
+	src, err := arrayScanSource(value)
+	if err != nil {
+		return err
+	}
+	attrs, err := ParseComposite(src)
+	if err != nil {
+		return err
+	}
+
+	var v T
+	sv, err := structPointerValue(&v)
+	if err != nil {
+		return err
+	}
+	fields := dbFields(sv.Type())
+	if len(fields) != len(attrs) {
+		return fmt.Errorf("composite has %d attributes, %s has %d fields", len(attrs), sv.Type(), len(fields))
+	}
+	for i, f := range fields {
+		fv := sv.FieldByIndex(f.index)
+		if err := scanCompositeAttr(fv, attrs[i]); err != nil {
+			return fmt.Errorf("attribute %q: %w", f.name, err)
+		}
+	}
+	c.V, c.Valid = v, true
+	return nil
+}
+
+func scanCompositeAttr(fv reflect.Value, attr NullString) error {
+	if scanner, ok := fv.Addr().Interface().(sql.Scanner); ok {
+		if !attr.Valid {
+			return scanner.Scan(nil)
+		}
+		return scanner.Scan(attr.String)
+	}
+	if fv.Kind() == reflect.String {
+		if !attr.Valid {
+			return fmt.Errorf("cannot scan NULL into string")
+		}
+		fv.SetString(attr.String)
+		return nil
+	}
+	return fmt.Errorf("unsupported field type %s", fv.Type())
+}
+
+// Value implements the driver.Valuer interface.
+func (c Composite[T]) Value() (driver.Value, error) {
+	if !c.Valid {
+		return nil, nil
+	}
+	sv := reflect.ValueOf(&c.V).Elem()
+	if sv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("composite type %s is not a struct", sv.Type())
+	}
+	fields := dbFields(sv.Type())
+	attrs := make([]NullString, len(fields))
+	for i, f := range fields {
+		attr, err := compositeAttrText(sv.FieldByIndex(f.index).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", f.name, err)
+		}
+		attrs[i] = attr
+	}
+	return FormatComposite(attrs), nil
+}
+
+// compositeAttrText returns the Postgres text form of a field value.
+func compositeAttrText(v interface{}) (NullString, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		if err != nil {
+			return NullString{}, err
+		}
+		v = dv
+	}
+	switch v := v.(type) {
+	case nil:
+		return NullString{}, nil
+	case string:
+		return *NewNullStringAllowEmpty(v), nil
+	case []byte:
+		return *NewNullStringAllowEmpty(`\x` + hex.EncodeToString(v)), nil
+	case int64:
+		return *NewNullStringAllowEmpty(strconv.FormatInt(v, 10)), nil
+	case float64:
+		return *NewNullStringAllowEmpty(strconv.FormatFloat(v, 'g', -1, 64)), nil
+	case bool:
+		if v {
+			return *NewNullStringAllowEmpty("t"), nil
+		}
+		return *NewNullStringAllowEmpty("f"), nil
+	case time.Time:
+		return *NewNullStringAllowEmpty(v.Format(time.RFC3339Nano)), nil
+	}
+	return NullString{}, fmt.Errorf("unsupported value type %T", v)
+}
@@ -0,0 +1,114 @@
+// composite_test.go
+package octypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseComposite(t *testing.T) {
+	fields, err := ParseComposite(`(1,"a b",,"say ""hi""","",back\,slash,"x\\y")`)
+	if err != nil {
+		t.Fatalf("Error parsing composite: %v", err)
+	}
+	want := []NullString{
+		*NewNullString("1"),
+		*NewNullString("a b"),
+		{},
+		*NewNullString(`say "hi"`),
+		*NewNullStringAllowEmpty(""),
+		*NewNullString("back,slash"),
+		*NewNullString(`x\y`),
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("Expected %d fields, got %d: %+v", len(want), len(fields), fields)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("Field %d: expected %+v, got %+v", i, want[i], fields[i])
+		}
+	}
+
+	fields, err = ParseComposite(`()`)
+	if err != nil || len(fields) != 1 || fields[0].Valid {
+		t.Errorf("Expected a single NULL field, got %+v and %v", fields, err)
+	}
+
+	for _, in := range []string{``, `1,2`, `("a)`} {
+		if _, err := ParseComposite(in); err == nil {
+			t.Errorf("Expected error when parsing %q, got nil", in)
+		}
+	}
+}
+
+func TestFormatComposite(t *testing.T) {
+	got := FormatComposite([]NullString{
+		*NewNullString("1"),
+		{},
+		*NewNullStringAllowEmpty(""),
+		*NewNullString(`a "b", \c`),
+	})
+	want := `(1,,"","a ""b"", \\c")`
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	parsed, err := ParseComposite(got)
+	if err != nil {
+		t.Fatalf("Error parsing formatted composite: %v", err)
+	}
+	if parsed[3].String != `a "b", \c` || parsed[1].Valid {
+		t.Errorf("Unexpected round trip result %+v", parsed)
+	}
+}
+
+type compositeAddress struct {
+	Street NullString `db:"street"`
+	Number NullInt64  `db:"number"`
+	Since  CustomTime `db:"since"`
+	Label  string     `db:"label"`
+}
+
+func TestComposite(t *testing.T) {
+	var c Composite[compositeAddress]
+	err := c.Scan([]byte(`("Main Street",12,"2020-01-02 03:04:05+00",home)`))
+	if err != nil {
+		t.Fatalf("Error scanning composite: %v", err)
+	}
+	if !c.Valid || c.V.Street.String != "Main Street" || c.V.Number.Int64 != 12 || c.V.Label != "home" {
+		t.Errorf("Unexpected composite %+v", c)
+	}
+	if !c.V.Since.Time.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected Since %v", c.V.Since.Time)
+	}
+
+	c.V.Number = NullInt64{}
+	val, err := c.Value()
+	if err != nil {
+		t.Fatalf("Error getting Value from composite: %v", err)
+	}
+	want := `("Main Street",,2020-01-02T03:04:05Z,home)`
+	if val != want {
+		t.Errorf("Expected Value %s, got %v", want, val)
+	}
+
+	if err := c.Scan(nil); err != nil || c.Valid {
+		t.Errorf("Expected null composite and no error, got %+v and %v", c, err)
+	}
+	if val, _ := c.Value(); val != nil {
+		t.Errorf("Expected nil Value, got %v", val)
+	}
+}
+
+func TestCompositeScanErrors(t *testing.T) {
+	var c Composite[compositeAddress]
+	if err := c.Scan(`(a,1)`); err == nil {
+		t.Errorf("Expected error for attribute count mismatch, got nil")
+	}
+	if err := c.Scan(`(a,x,,b)`); err == nil {
+		t.Errorf("Expected error for invalid integer attribute, got nil")
+	}
+	if err := c.Scan(`(a,1,,)`); err == nil {
+		t.Errorf("Expected error for NULL into string field, got nil")
+	}
+}
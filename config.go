@@ -0,0 +1,252 @@
+// config.go
+package octypes
+
+import "time"
+
+// Package-level settings. They are read without synchronization, so they
+// should be configured during program initialization, before any value is
+// marshalled or scanned.
+var (
+	defaultBytesEncoding = BytesEncodingBase64
+	strictNumericScan    = false
+	extraTimeLayouts     []string
+	mysqlCompat          = false
+	sqliteTimeStorage    = SQLiteTimeISO8601
+	jsonValueMode        = JSONValueBytes
+	timeValueLayout      = ""
+	boolValueMode        = BoolValueNative
+	timeJSONFormat       = TimeFormatObject
+	timeZonePreserve     = false
+	unixTimestampPolicy  = UnixPolicyMillis
+	timeFormatter        = TimeFormatter(englishTimeFormatter{})
+	localizedTimeLocale  = ""
+	localizedTimeStyle   = TimeStyleMedium
+	relativeTimeOutput   = false
+	minTimeYear          = 1
+	maxTimeYear          = 9999
+	nowFunc              = time.Now
+	timeMarshaler        TimeMarshaler
+	timeMarshalPrecision time.Duration
+	defaultLanguage      = ""
+	languageTagMode      = LanguageTagsAsIs
+	textInternPool       *InternPool
+	textMaxLength        = 0
+	textSanitizer        func(lang, value string) string
+	paginationDefaults   = PaginationDefaults{PerPage: 20, MaxPerPage: 100}
+	internEnabled        = false
+	zeroCopyStrings      = false
+	timeMarshalCache     = false
+	defaultInternPool    = NewInternPoolWithOptions(defaultInternOptions)
+)
+
+// SetBytesEncoding sets the text encoding used by NullBytes values whose
+// Encoding field is BytesEncodingDefault.
+func SetBytesEncoding(enc BytesEncoding) {
+	if enc == BytesEncodingDefault {
+		enc = BytesEncodingBase64
+	}
+	defaultBytesEncoding = enc
+}
+
+// SetStrictNumericScan makes NullFloat64.Scan return ErrPrecisionLoss for
+// textual numeric values, such as Postgres NUMERIC, that cannot be
+// represented exactly as a float64. It is off by default, in which case
+// values are rounded to the nearest float64.
+func SetStrictNumericScan(strict bool) {
+	strictNumericScan = strict
+}
+
+// SetMySQLCompat enables MySQL compatibility for CustomTime.Scan: zero
+// dates such as "0000-00-00 00:00:00", and the zero time.Time returned for
+// them by go-sql-driver/mysql with parseTime=true, scan as null instead of
+// failing or producing year 0.
+func SetMySQLCompat(enabled bool) {
+	mysqlCompat = enabled
+}
+
+// RegisterTimeLayout registers additional time.Parse layouts tried, in
+// registration order, after the built-in ones when CustomTime parses text
+// in Scan or a JSON string in UnmarshalJSON.
+func RegisterTimeLayout(layouts ...string) {
+	extraTimeLayouts = append(extraTimeLayouts, layouts...)
+}
+
+// SetSQLiteTimeStorage selects how CustomTime.Scan interprets integer and
+// REAL sources, which is how SQLite hands over times not stored as text.
+func SetSQLiteTimeStorage(storage SQLiteTimeStorage) {
+	sqliteTimeStorage = storage
+}
+
+// SetJSONValueMode selects what Value returns for the JSON-backed map types
+// LocalizedText and IntDictionary.
+func SetJSONValueMode(mode JSONValueMode) {
+	jsonValueMode = mode
+}
+
+// SetTimeValueLayout makes CustomTime.Value return the time formatted with
+// layout instead of a time.Time, for drivers that expect textual times such
+// as TimeLayoutSQLServer or TimeLayoutOracle. An empty layout restores the
+// default.
+func SetTimeValueLayout(layout string) {
+	timeValueLayout = layout
+}
+
+// SetBoolValueMode selects what NullBool.Value returns.
+func SetBoolValueMode(mode BoolValueMode) {
+	boolValueMode = mode
+}
+
+// SetTimeJSONFormat selects the JSON representation of CustomTime for every
+// value, without changing struct definitions.
+func SetTimeJSONFormat(format TimeJSONFormat) {
+	timeJSONFormat = format
+}
+
+// SetTimeZonePreserve makes CustomTime.UnmarshalJSON and UnmarshalBinary
+// restore the location named in the encoded value (the tz field of a
+// TimeResponse), so a time set in "Europe/Paris" comes back in
+// "Europe/Paris" rather than in a fixed offset or UTC. Names that cannot be
+// loaded with time.LoadLocation are ignored.
+func SetTimeZonePreserve(enabled bool) {
+	timeZonePreserve = enabled
+}
+
+// SetUnixTimestampPolicy selects how CustomTime interprets bare Unix
+// timestamps in UnmarshalJSON, NewCustomTimeInt64, NewCustomTimeFloat64 and
+// integer text passed to Scan.
+func SetUnixTimestampPolicy(policy UnixTimestampPolicy) {
+	unixTimestampPolicy = policy
+}
+
+// SetTimeFormatter sets the TimeFormatter used by CustomTime.Format and the
+// localized TimeResponse field. A nil formatter restores the default, which
+// formats every locale in US English. It clears the marshal cache of
+// SetTimeMarshalCache.
+func SetTimeFormatter(f TimeFormatter) {
+	if f == nil {
+		f = englishTimeFormatter{}
+	}
+	timeFormatter = f
+	clearTimeCache()
+}
+
+// SetTimeLocalizedOutput makes CustomTime.MarshalJSON fill the localized
+// TimeResponse field with the time formatted for locale in style. An empty
+// locale disables the field, which is the default.
+func SetTimeLocalizedOutput(locale string, style TimeStyle) {
+	localizedTimeLocale = locale
+	localizedTimeStyle = style
+}
+
+// SetTimeRelativeOutput makes CustomTime.MarshalJSON fill the relative
+// TimeResponse field with Humanize, e.g. "3 hours ago". It is off by
+// default.
+func SetTimeRelativeOutput(enabled bool) {
+	relativeTimeOutput = enabled
+}
+
+// SetTimeYearRange sets the inclusive range of years accepted by
+// CustomTime.Scan and UnmarshalJSON; other times fail with
+// ErrTimeOutOfRange. The default, 1 to 9999, matches what RFC 3339 can
+// represent. SetTimeYearRange(0, 0) disables the check.
+func SetTimeYearRange(min, max int) {
+	minTimeYear, maxTimeYear = min, max
+}
+
+// SetNowFunc sets the clock used by NewCustomTimeNow, Humanize, the
+// relative TimeResponse field and InternPool expiry, so tests can freeze
+// time. A nil f restores time.Now.
+func SetNowFunc(f func() time.Time) {
+	if f == nil {
+		f = time.Now
+	}
+	nowFunc = f
+}
+
+// SetTimeMarshaler replaces the JSON encoding of valid CustomTime values,
+// overriding SetTimeJSONFormat. Null values still marshal as null. A nil m
+// restores the built-in encoding.
+func SetTimeMarshaler(m TimeMarshaler) {
+	timeMarshaler = m
+}
+
+// SetTimeMarshalPrecision truncates times marshalled by CustomTime.MarshalJSON
+// to a multiple of precision, e.g. time.Second, time.Millisecond or
+// time.Microsecond, so the iso, us and full fields carry no sub-precision
+// noise. Zero keeps nanoseconds, which is the default.
+func SetTimeMarshalPrecision(precision time.Duration) {
+	timeMarshalPrecision = precision
+}
+
+// SetDefaultLanguage sets the language LocalizedText.Resolve falls back to
+// when neither the requested tag nor its parents have a value.
+func SetDefaultLanguage(lang string) {
+	defaultLanguage = lang
+}
+
+// SetLanguageTagMode selects how LocalizedText keys are validated and
+// canonicalized by Scan and UnmarshalJSON.
+func SetLanguageTagMode(mode LanguageTagMode) {
+	languageTagMode = mode
+}
+
+// SetLocalizedTextInterning makes LocalizedText.Scan and UnmarshalJSON
+// intern keys and values in pool, deduplicating the locale keys and common
+// values repeated across rows. It takes precedence over SetInterning. A nil
+// pool restores the default.
+func SetLocalizedTextInterning(pool *InternPool) {
+	textInternPool = pool
+}
+
+// SetLocalizedTextMaxLength makes LocalizedText.Scan and UnmarshalJSON fail
+// with ErrValueTooLong for values longer than n runes, after sanitization.
+// Zero, the default, disables the limit.
+func SetLocalizedTextMaxLength(n int) {
+	textMaxLength = n
+}
+
+// SetLocalizedTextSanitizer sets a function applied to every value decoded
+// by LocalizedText.Scan and UnmarshalJSON, e.g. StripControlChars or an
+// HTML sanitizer. A nil f disables sanitization, which is the default.
+func SetLocalizedTextSanitizer(f func(lang, value string) string) {
+	textSanitizer = f
+}
+
+// SetPaginationDefaults sets the pagination policy. Zero PerPage and
+// MaxPerPage keep their built-in defaults.
+func SetPaginationDefaults(d PaginationDefaults) {
+	if d.PerPage <= 0 {
+		d.PerPage = 20
+	}
+	if d.MaxPerPage <= 0 {
+		d.MaxPerPage = 100
+	}
+	paginationDefaults = d
+}
+
+// defaultInternOptions configures the default InternPool.
+var defaultInternOptions = InternOptions{Size: 10000}
+
+// SetInterning makes LocalizedText and IntDictionary decoding intern keys,
+// and LocalizedText values, in DefaultInternPool. It is off by default.
+func SetInterning(enabled bool) {
+	internEnabled = enabled
+}
+
+// ConfigureInternPool replaces DefaultInternPool with an empty pool
+// configured by opts.
+func ConfigureInternPool(opts InternOptions) {
+	defaultInternPool = NewInternPoolWithOptions(opts)
+}
+
+// SetZeroCopyStrings makes NullString.UnmarshalJSON reference the JSON
+// input instead of copying it when the string needs no unescaping, saving
+// an allocation per value on large string fields. It is off by default and
+// unsafe unless the input is owned by the decoded values: decoded strings
+// alias the bytes passed to json.Unmarshal, so those bytes must never be
+// modified or reused afterwards, and must stay reachable as long as any
+// decoded string is (which the garbage collector ensures). Do not enable it
+// when decoding with json.Decoder, which reuses its read buffer.
+func SetZeroCopyStrings(enabled bool) {
+	zeroCopyStrings = enabled
+}
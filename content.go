@@ -0,0 +1,94 @@
+// content.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// ContentFormat is the markup of a LocalizedContent value.
+type ContentFormat string
+
+// Supported content formats.
+const (
+	ContentPlain    ContentFormat = "plain"
+	ContentMarkdown ContentFormat = "markdown"
+	ContentHTML     ContentFormat = "html"
+)
+
+// Content is a text with its format.
+type Content struct {
+	Text   string        `json:"text"`
+	Format ContentFormat `json:"format"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A bare JSON
+// string is accepted as plain text, so LocalizedText columns can be read as
+// LocalizedContent. A missing format means plain.
+func (c *Content) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*c = Content{Text: s, Format: ContentPlain}
+		return nil
+	}
+	var v struct {
+		Text   string        `json:"text"`
+		Format ContentFormat `json:"format"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v.Format {
+	case "":
+		v.Format = ContentPlain
+	case ContentPlain, ContentMarkdown, ContentHTML:
+	default:
+		return fmt.Errorf("unknown content format %q", v.Format)
+	}
+	*c = Content(v)
+	return nil
+}
+
+// LocalizedContent represents a map of localized texts carrying their
+// format, e.g. {"en": {"text": "**Hi**", "format": "markdown"}}.
+type LocalizedContent map[string]Content
+
+// Texts returns the texts of lc without their formats, e.g. to use
+// LocalizedText.Resolve.
+func (lc LocalizedContent) Texts() LocalizedText {
+	if lc == nil {
+		return nil
+	}
+	lt := make(LocalizedText, len(lc))
+	for k, c := range lc {
+		lt[k] = c.Text
+	}
+	return lt
+}
+
+// Scan implements the sql.Scanner interface.
+func (lc *LocalizedContent) Scan(value interface{}) error {
+	if value == nil {
+		*lc = nil
+		return nil
+	}
+	asBytes, err := jsonScanSource(value)
+	if err != nil {
+		return err
+	}
+	var m map[string]Content
+	if err := json.Unmarshal(asBytes, &m); err != nil {
+		return err
+	}
+	*lc = m
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (lc LocalizedContent) Value() (driver.Value, error) {
+	if lc == nil {
+		return nil, nil
+	}
+	return jsonValue(lc, map[string]Content(lc))
+}
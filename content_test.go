@@ -0,0 +1,43 @@
+// content_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLocalizedContent(t *testing.T) {
+	var lc LocalizedContent
+	err := json.Unmarshal([]byte(`{"en":{"text":"**Hi**","format":"markdown"},"fr":"Salut","de":{"text":"Hallo"}}`), &lc)
+	if err != nil {
+		t.Fatalf("Error unmarshalling LocalizedContent: %v", err)
+	}
+	if lc["en"] != (Content{"**Hi**", ContentMarkdown}) {
+		t.Errorf("Expected markdown content, got %+v", lc["en"])
+	}
+	if lc["fr"] != (Content{"Salut", ContentPlain}) || lc["de"].Format != ContentPlain {
+		t.Errorf("Expected plain content, got %+v and %+v", lc["fr"], lc["de"])
+	}
+	if v, _, _ := lc.Texts().Resolve("fr-CA"); v != "Salut" {
+		t.Errorf("Expected Salut, got %q", v)
+	}
+
+	v, err := lc.Value()
+	if err != nil {
+		t.Fatalf("Error getting Value: %v", err)
+	}
+	var scanned LocalizedContent
+	if err := scanned.Scan(v); err != nil || scanned["en"] != lc["en"] || len(scanned) != 3 {
+		t.Errorf("Expected round trip, got %+v and error %v", scanned, err)
+	}
+
+	if err := scanned.Scan(`{"en":{"text":"x","format":"rtf"}}`); err == nil {
+		t.Errorf("Expected error for unknown format, got nil")
+	}
+	if err := scanned.Scan(nil); err != nil || scanned != nil {
+		t.Errorf("Expected nil after scanning NULL, got %v", scanned)
+	}
+	if err := scanned.Scan(42); err == nil {
+		t.Errorf("Expected error for invalid Scan source, got nil")
+	}
+}
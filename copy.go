@@ -0,0 +1,325 @@
+// copy.go
+package octypes
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Postgres type OIDs used by the binary encoders.
+const (
+	OIDBool        uint32 = 16
+	OIDBytea       uint32 = 17
+	OIDInt8        uint32 = 20
+	OIDInt2        uint32 = 21
+	OIDInt4        uint32 = 23
+	OIDText        uint32 = 25
+	OIDJSON        uint32 = 114
+	OIDFloat4      uint32 = 700
+	OIDFloat8      uint32 = 701
+	OIDDate        uint32 = 1082
+	OIDTimestamp   uint32 = 1114
+	OIDTimestamptz uint32 = 1184
+	OIDJSONB       uint32 = 3802
+)
+
+// copySignature starts every COPY binary stream.
+var copySignature = []byte("PGCOPY\n\xff\r\n\x00")
+
+// postgresEpoch is the zero point of Postgres binary dates and timestamps.
+var postgresEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CopyBinaryEncoder writes structs in the Postgres binary COPY format, for
+// use with COPY table (columns) FROM STDIN (FORMAT binary). Columns are
+// taken from `db` tags in declaration order; CopyColumns returns them.
+//
+// Values are encoded according to their Go type: strings as text, integers
+// as int8, floats as float8, times as timestamptz and maps as jsonb. A
+// `pgtype` tag selects another binary representation when the column type
+// differs, e.g. `pgtype:"int4"`, `pgtype:"date"` or `pgtype:"json"`. Nil
+// pointers are written as NULL, and `ocnull` tags are applied as by Args.
+type CopyBinaryEncoder struct {
+	w           io.Writer
+	buf         []byte
+	wroteHeader bool
+}
+
+// NewCopyBinaryEncoder creates a CopyBinaryEncoder writing to w.
+func NewCopyBinaryEncoder(w io.Writer) *CopyBinaryEncoder {
+	return &CopyBinaryEncoder{w: w}
+}
+
+// CopyColumns returns the column names CopyBinaryEncoder writes for the
+// struct type of v, which may be a struct or a pointer to one. They are the
+// same as Columns.
+func CopyColumns(v interface{}) []string {
+	return Columns(v)
+}
+
+// Encode writes row, a struct or a pointer to one, as one tuple.
+func (e *CopyBinaryEncoder) Encode(row interface{}) error {
+	rv, err := structValueOf(row)
+	if err != nil {
+		return err
+	}
+
+	buf := e.buf[:0]
+	if !e.wroteHeader {
+		buf = append(buf, copySignature...)
+		buf = binary.BigEndian.AppendUint32(buf, 0) // flags
+		buf = binary.BigEndian.AppendUint32(buf, 0) // header extension length
+	}
+
+	fields := dbFields(rv.Type())
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(fields)))
+	for _, f := range fields {
+		sf := rv.Type().FieldByIndex(f.index)
+		v, err := f.arg(rv)
+		if err != nil {
+			return err
+		}
+		buf, err = appendBinaryField(buf, v, sf.Tag.Get("pgtype"))
+		if err != nil {
+			return fmt.Errorf("column %q: %w", f.name, err)
+		}
+	}
+
+	e.buf = buf
+	if _, err := e.w.Write(buf); err != nil {
+		return err
+	}
+	e.wroteHeader = true
+	return nil
+}
+
+// Close writes the stream trailer. It does not close the underlying writer.
+func (e *CopyBinaryEncoder) Close() error {
+	buf := e.buf[:0]
+	if !e.wroteHeader {
+		buf = append(buf, copySignature...)
+		buf = binary.BigEndian.AppendUint32(buf, 0)
+		buf = binary.BigEndian.AppendUint32(buf, 0)
+	}
+	buf = binary.BigEndian.AppendUint16(buf, 0xffff)
+	_, err := e.w.Write(buf)
+	e.wroteHeader = true
+	return err
+}
+
+// appendBinaryField appends a length-prefixed field, or -1 for NULL.
+func appendBinaryField(buf []byte, v interface{}, pgType string) ([]byte, error) {
+	start := len(buf)
+	buf = append(buf, 0, 0, 0, 0)
+	buf, null, err := appendBinaryValue(buf, v, pgType)
+	if err != nil {
+		return nil, err
+	}
+	if null {
+		binary.BigEndian.PutUint32(buf[start:], math.MaxUint32) // -1
+		return buf[:start+4], nil
+	}
+	binary.BigEndian.PutUint32(buf[start:], uint32(len(buf)-start-4))
+	return buf, nil
+}
+
+// appendBinaryValue appends the Postgres binary representation of v. A nil
+// pointer is NULL and other pointers are encoded as the value they point
+// to.
+func appendBinaryValue(buf []byte, v interface{}, pgType string) ([]byte, bool, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return buf, true, nil
+		}
+		v = rv.Elem().Interface()
+	}
+	switch v := v.(type) {
+	case NullString:
+		if !v.Valid {
+			return buf, true, nil
+		}
+		return append(buf, v.String...), false, nil
+	case string:
+		return append(buf, v...), false, nil
+	case NullInt64:
+		if !v.Valid {
+			return buf, true, nil
+		}
+		return appendBinaryInt(buf, v.Int64, pgType)
+	case int64:
+		return appendBinaryInt(buf, v, pgType)
+	case int:
+		return appendBinaryInt(buf, int64(v), pgType)
+	case int32:
+		return appendBinaryInt(buf, int64(v), pgType)
+	case int16:
+		return appendBinaryInt(buf, int64(v), pgType)
+	case NullFloat64:
+		if !v.Valid {
+			return buf, true, nil
+		}
+		return appendBinaryFloat(buf, v.Float64, pgType)
+	case float64:
+		return appendBinaryFloat(buf, v, pgType)
+	case float32:
+		return appendBinaryFloat(buf, float64(v), pgType)
+	case NullBool:
+		if !v.Valid {
+			return buf, true, nil
+		}
+		return appendBinaryBool(buf, v.Bool), false, nil
+	case bool:
+		return appendBinaryBool(buf, v), false, nil
+	case CustomTime:
+		if !v.Valid {
+			return buf, true, nil
+		}
+		return appendBinaryTime(buf, v.Time, pgType)
+	case time.Time:
+		return appendBinaryTime(buf, v, pgType)
+	case NullBytes:
+		if !v.Valid {
+			return buf, true, nil
+		}
+		return append(buf, v.Bytes...), false, nil
+	case []byte:
+		if v == nil {
+			return buf, true, nil
+		}
+		return append(buf, v...), false, nil
+	case LocalizedText:
+		if v == nil {
+			return buf, true, nil
+		}
+		return appendBinaryJSON(buf, v, pgType)
+	case IntDictionary:
+		if v == nil {
+			return buf, true, nil
+		}
+		return appendBinaryJSON(buf, v, pgType)
+	case NullStringArray:
+		if v == nil {
+			return buf, true, nil
+		}
+		return appendBinaryArray(buf, OIDText, len(v), func(buf []byte, i int) ([]byte, bool, error) {
+			return appendBinaryValue(buf, v[i], "")
+		})
+	case NullInt64Array:
+		if v == nil {
+			return buf, true, nil
+		}
+		return appendBinaryArray(buf, OIDInt8, len(v), func(buf []byte, i int) ([]byte, bool, error) {
+			return appendBinaryValue(buf, v[i], "")
+		})
+	}
+	return nil, false, fmt.Errorf("unsupported type %T", v)
+}
+
+func appendBinaryInt(buf []byte, n int64, pgType string) ([]byte, bool, error) {
+	switch pgType {
+	case "", "int8":
+		return binary.BigEndian.AppendUint64(buf, uint64(n)), false, nil
+	case "int4":
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, false, fmt.Errorf("value %d out of range for int4", n)
+		}
+		return binary.BigEndian.AppendUint32(buf, uint32(n)), false, nil
+	case "int2":
+		if n < math.MinInt16 || n > math.MaxInt16 {
+			return nil, false, fmt.Errorf("value %d out of range for int2", n)
+		}
+		return binary.BigEndian.AppendUint16(buf, uint16(n)), false, nil
+	}
+	return nil, false, fmt.Errorf("cannot encode integer as %s", pgType)
+}
+
+func appendBinaryFloat(buf []byte, f float64, pgType string) ([]byte, bool, error) {
+	switch pgType {
+	case "", "float8":
+		return binary.BigEndian.AppendUint64(buf, math.Float64bits(f)), false, nil
+	case "float4":
+		return binary.BigEndian.AppendUint32(buf, math.Float32bits(float32(f))), false, nil
+	}
+	return nil, false, fmt.Errorf("cannot encode float as %s", pgType)
+}
+
+func appendBinaryBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+func appendBinaryTime(buf []byte, t time.Time, pgType string) ([]byte, bool, error) {
+	switch pgType {
+	case "", "timestamptz":
+		return binary.BigEndian.AppendUint64(buf, uint64(t.UnixMicro()-postgresEpoch.UnixMicro())), false, nil
+	case "timestamp":
+		// timestamp stores the wall clock reading without a zone.
+		wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+		return binary.BigEndian.AppendUint64(buf, uint64(wall.UnixMicro()-postgresEpoch.UnixMicro())), false, nil
+	case "date":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		days := (day.Unix() - postgresEpoch.Unix()) / 86400
+		return binary.BigEndian.AppendUint32(buf, uint32(int32(days))), false, nil
+	}
+	return nil, false, fmt.Errorf("cannot encode time as %s", pgType)
+}
+
+func appendBinaryJSON(buf []byte, v interface{}, pgType string) ([]byte, bool, error) {
+	switch pgType {
+	case "", "jsonb":
+		buf = append(buf, 1) // jsonb format version
+	case "json":
+	default:
+		return nil, false, fmt.Errorf("cannot encode JSON as %s", pgType)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, false, err
+	}
+	return append(buf, b...), false, nil
+}
+
+// appendBinaryArray appends a one-dimensional array with n elements of
+// type elemOID, each appended by elem.
+func appendBinaryArray(buf []byte, elemOID uint32, n int, elem func([]byte, int) ([]byte, bool, error)) ([]byte, bool, error) {
+	ndim := uint32(1)
+	if n == 0 {
+		ndim = 0
+	}
+	buf = binary.BigEndian.AppendUint32(buf, ndim)
+	hasNullAt := len(buf)
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, elemOID)
+	if n == 0 {
+		return buf, false, nil
+	}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(n))
+	buf = binary.BigEndian.AppendUint32(buf, 1) // lower bound
+
+	for i := 0; i < n; i++ {
+		start := len(buf)
+		buf = append(buf, 0, 0, 0, 0)
+		var (
+			null bool
+			err  error
+		)
+		buf, null, err = elem(buf, i)
+		if err != nil {
+			return nil, false, err
+		}
+		if null {
+			binary.BigEndian.PutUint32(buf[hasNullAt:], 1)
+			binary.BigEndian.PutUint32(buf[start:], math.MaxUint32)
+			buf = buf[:start+4]
+			continue
+		}
+		binary.BigEndian.PutUint32(buf[start:], uint32(len(buf)-start-4))
+	}
+	return buf, false, nil
+}
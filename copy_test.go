@@ -0,0 +1,154 @@
+// copy_test.go
+package octypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+type copyRow struct {
+	ID      NullInt64       `db:"id"`
+	Name    NullString      `db:"name"`
+	Age     int64           `db:"age" pgtype:"int4"`
+	Active  NullBool        `db:"active"`
+	Born    CustomTime      `db:"born" pgtype:"date"`
+	Labels  LocalizedText   `db:"labels"`
+	Tags    NullStringArray `db:"tags"`
+	Ignored string
+}
+
+func be16(n uint16) []byte { return binary.BigEndian.AppendUint16(nil, n) }
+func be32(n uint32) []byte { return binary.BigEndian.AppendUint32(nil, n) }
+func be64(n uint64) []byte { return binary.BigEndian.AppendUint64(nil, n) }
+
+func TestCopyColumns(t *testing.T) {
+	got := CopyColumns(&copyRow{})
+	want := []string{"id", "name", "age", "active", "born", "labels", "tags"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected columns %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected column %d to be %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestCopyBinaryEncoder(t *testing.T) {
+	var out bytes.Buffer
+	enc := NewCopyBinaryEncoder(&out)
+	row := copyRow{
+		ID:     *NewNullInt64(1),
+		Age:    30,
+		Active: *NewNullBool(true),
+		Born:   *NewCustomTime(time.Date(2000, 1, 3, 15, 0, 0, 0, time.UTC)),
+		Labels: LocalizedText{"en": "x"},
+		Tags:   NullStringArray{*NewNullString("a"), {}},
+	}
+	if err := enc.Encode(&row); err != nil {
+		t.Fatalf("Error encoding row: %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Error closing encoder: %v", err)
+	}
+
+	var want bytes.Buffer
+	want.WriteString("PGCOPY\n\xff\r\n\x00")
+	want.Write(be32(0))
+	want.Write(be32(0))
+	want.Write(be16(7))
+	want.Write(be32(8))
+	want.Write(be64(1))
+	want.Write(be32(0xffffffff))
+	want.Write(be32(4))
+	want.Write(be32(30))
+	want.Write(be32(1))
+	want.WriteByte(1)
+	want.Write(be32(4))
+	want.Write(be32(2))
+	want.Write(be32(11))
+	want.WriteString("\x01{\"en\":\"x\"}")
+	want.Write(be32(29))
+	want.Write(be32(1))          // ndim
+	want.Write(be32(1))          // has nulls
+	want.Write(be32(OIDText))    // element type
+	want.Write(be32(2))          // length
+	want.Write(be32(1))          // lower bound
+	want.Write(be32(1))          // element length
+	want.WriteByte('a')          // element
+	want.Write(be32(0xffffffff)) // NULL element
+	want.Write(be16(0xffff))
+
+	if !bytes.Equal(out.Bytes(), want.Bytes()) {
+		t.Errorf("Unexpected COPY stream\nwant %q\ngot  %q", want.Bytes(), out.Bytes())
+	}
+}
+
+func TestCopyBinaryEncoderTimestamptz(t *testing.T) {
+	buf, null, err := appendBinaryValue(nil, *NewCustomTime(time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)), "")
+	if err != nil || null {
+		t.Fatalf("Expected no error and non-null, got %v and %v", err, null)
+	}
+	if !bytes.Equal(buf, be64(1000000)) {
+		t.Errorf("Expected 1000000 microseconds, got %x", buf)
+	}
+}
+
+func TestCopyBinaryEncoderPointersAndNullPolicy(t *testing.T) {
+	type row struct {
+		Name  *NullString `db:"name"`
+		Count *int64      `db:"count"`
+		Bio   NullString  `db:"bio" ocnull:"emptyisnull"`
+		Note  NullString  `db:"note" ocnull:"nullisempty"`
+	}
+	n := int64(7)
+	var out bytes.Buffer
+	enc := NewCopyBinaryEncoder(&out)
+	if err := enc.Encode(row{Count: &n, Bio: *NewNullStringAllowEmpty("")}); err != nil {
+		t.Fatalf("Error encoding row: %v", err)
+	}
+
+	var want bytes.Buffer
+	want.WriteString("PGCOPY\n\xff\r\n\x00")
+	want.Write(be32(0))
+	want.Write(be32(0))
+	want.Write(be16(4))
+	want.Write(be32(0xffffffff)) // nil pointer
+	want.Write(be32(8))
+	want.Write(be64(7))
+	want.Write(be32(0xffffffff)) // empty string made null
+	want.Write(be32(0))          // null made empty string
+	if !bytes.Equal(out.Bytes(), want.Bytes()) {
+		t.Errorf("Unexpected COPY stream\nwant %q\ngot  %q", want.Bytes(), out.Bytes())
+	}
+
+	type badPolicy struct {
+		N NullString `db:"n" ocnull:"sometimes"`
+	}
+	if err := enc.Encode(badPolicy{}); err == nil {
+		t.Errorf("Expected error for unknown ocnull policy, got nil")
+	}
+}
+
+func TestCopyBinaryEncoderErrors(t *testing.T) {
+	enc := NewCopyBinaryEncoder(&bytes.Buffer{})
+	if err := enc.Encode(42); err == nil {
+		t.Errorf("Expected error when encoding non-struct, got nil")
+	}
+
+	type badRange struct {
+		N int64 `db:"n" pgtype:"int2"`
+	}
+	if err := enc.Encode(badRange{N: 1 << 20}); err == nil {
+		t.Errorf("Expected error when value overflows int2, got nil")
+	}
+
+	type unsupported struct {
+		C chan int `db:"c"`
+	}
+	if err := enc.Encode(unsupported{}); err == nil {
+		t.Errorf("Expected error for unsupported field type, got nil")
+	}
+}
@@ -0,0 +1,88 @@
+// decoderstate.go
+package octypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxDecoderScratch is the scratch size above which a released
+// DecoderState drops its buffer rather than pinning it in the pool.
+const maxDecoderScratch = 1 << 20
+
+// DecoderState carries the scratch memory of bulk decode loops, a buffer
+// and a bytes.Reader, so that decoding a record does not allocate them
+// anew. States come from a pool:
+//
+//	s := octypes.AcquireDecoderState()
+//	defer s.Release()
+//	for _, rec := range records {
+//		if err := s.DecodeFrom(rec.Body, &row); err != nil { ... }
+//	}
+//
+// Decoded values never reference the scratch buffer, except with
+// SetZeroCopyStrings enabled, which must not be combined with a
+// DecoderState. A DecoderState is not safe for concurrent use.
+type DecoderState struct {
+	buf    []byte
+	reader bytes.Reader
+}
+
+var decoderStatePool = sync.Pool{
+	New: func() interface{} { return new(DecoderState) },
+}
+
+// AcquireDecoderState returns a DecoderState from the pool.
+func AcquireDecoderState() *DecoderState {
+	return decoderStatePool.Get().(*DecoderState)
+}
+
+// Release returns s to the pool. s must not be used afterwards.
+func (s *DecoderState) Release() {
+	if cap(s.buf) > maxDecoderScratch {
+		s.buf = nil
+	}
+	s.reader.Reset(nil)
+	decoderStatePool.Put(s)
+}
+
+// Scratch returns a buffer of length n that is reused by later calls, and
+// by DecodeFrom.
+func (s *DecoderState) Scratch(n int) []byte {
+	if cap(s.buf) < n {
+		s.buf = make([]byte, n)
+	}
+	return s.buf[:n]
+}
+
+// Reader returns the state's bytes.Reader reset to read b, for decoders
+// that need an io.Reader over a record held in memory.
+func (s *DecoderState) Reader(b []byte) *bytes.Reader {
+	s.reader.Reset(b)
+	return &s.reader
+}
+
+// DecodeFrom reads r to EOF into the scratch buffer and decodes the JSON
+// it holds into v. Records already in memory need no buffer and can be
+// passed to json.Unmarshal directly.
+func (s *DecoderState) DecodeFrom(r io.Reader, v interface{}) error {
+	buf := s.buf[:0]
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.buf = buf
+			return err
+		}
+	}
+	s.buf = buf
+	return json.Unmarshal(buf, v)
+}
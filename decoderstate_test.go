@@ -0,0 +1,80 @@
+// decoderstate_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type decoderRecord struct {
+	ID   NullInt64     `json:"id"`
+	Name NullString    `json:"name"`
+	Text LocalizedText `json:"text"`
+}
+
+func TestDecoderState(t *testing.T) {
+	s := AcquireDecoderState()
+	defer s.Release()
+
+	var rec decoderRecord
+	long := strings.Repeat("x", 5000)
+	for i, body := range []string{`{"id":1,"name":"a","text":{"en":"Hi"}}`, `{"id":2,"name":"` + long + `"}`, `{"id":3,"name":null}`} {
+		rec = decoderRecord{}
+		if err := s.DecodeFrom(iotest.OneByteReader(strings.NewReader(body)), &rec); err != nil {
+			t.Fatal(err)
+		}
+		if rec.ID.Int64 != int64(i+1) {
+			t.Errorf("Expected id %d, got %d", i+1, rec.ID.Int64)
+		}
+	}
+	if rec.Name.Valid {
+		t.Errorf("Expected a null name")
+	}
+
+	// Strings must not alias the reused buffer.
+	var first decoderRecord
+	s.DecodeFrom(strings.NewReader(`{"name":"first"}`), &first)
+	s.DecodeFrom(strings.NewReader(`{"name":"other"}`), &rec)
+	if first.Name.String != "first" {
+		t.Errorf("Expected first, got %s", first.Name.String)
+	}
+
+	boom := errors.New("boom")
+	if err := s.DecodeFrom(iotest.ErrReader(boom), &rec); !errors.Is(err, boom) {
+		t.Errorf("Expected the read error, got %v", err)
+	}
+
+	b, _ := io.ReadAll(s.Reader([]byte("abc")))
+	if string(b) != "abc" || len(s.Scratch(10)) != 10 {
+		t.Errorf("Unexpected reader or scratch behaviour")
+	}
+}
+
+func BenchmarkDecoderState(b *testing.B) {
+	body := `{"id":1,"name":"Chair","text":{"en":"Chair","fr":"Chaise"}}`
+	b.Run("DecoderState", func(b *testing.B) {
+		b.ReportAllocs()
+		s := AcquireDecoderState()
+		defer s.Release()
+		var r strings.Reader
+		for i := 0; i < b.N; i++ {
+			var rec decoderRecord
+			r.Reset(body)
+			s.DecodeFrom(&r, &rec)
+		}
+	})
+	b.Run("ReadAll", func(b *testing.B) {
+		b.ReportAllocs()
+		var r strings.Reader
+		for i := 0; i < b.N; i++ {
+			var rec decoderRecord
+			r.Reset(body)
+			data, _ := io.ReadAll(&r)
+			json.Unmarshal(data, &rec)
+		}
+	})
+}
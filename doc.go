@@ -0,0 +1,13 @@
+// doc.go
+
+// Package octypes provides nullable types for database and JSON APIs.
+//
+// # Layout
+//
+// NullString, NullInt64, NullFloat64, NullBool and CustomTime embed the
+// matching sql.Null* struct and nothing else, so they keep the Scan and
+// Value behaviour of database/sql and share its memory layout. JSON
+// encoding and decoding read and write the embedded fields in place; there
+// is no second, optimized representation to copy values into, and a v2 API
+// is not needed to remove one. TestPublicTypesLayout keeps it that way.
+package octypes
@@ -0,0 +1,151 @@
+// env.go
+package octypes
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NullStringFromEnv returns the environment variable key, null if it is
+// unset. A variable set to "" gives a valid empty string.
+func NullStringFromEnv(key string) NullString {
+	var ns NullString
+	if v, ok := os.LookupEnv(key); ok {
+		ns.NullString = sql.NullString{String: v, Valid: true}
+	}
+	return ns
+}
+
+// NullInt64FromEnv parses the environment variable key as an integer. It
+// is null if the variable is unset or empty.
+func NullInt64FromEnv(key string) (NullInt64, error) {
+	var ni NullInt64
+	err := scanEnv(key, &ni)
+	return ni, err
+}
+
+// NullFloat64FromEnv parses the environment variable key as a float. It is
+// null if the variable is unset or empty.
+func NullFloat64FromEnv(key string) (NullFloat64, error) {
+	var nf NullFloat64
+	err := scanEnv(key, &nf)
+	return nf, err
+}
+
+// NullBoolFromEnv parses the environment variable key as strconv.ParseBool
+// does. It is null if the variable is unset or empty.
+func NullBoolFromEnv(key string) (NullBool, error) {
+	var nb NullBool
+	err := scanEnv(key, &nb)
+	return nb, err
+}
+
+// CustomTimeFromEnv parses the environment variable key in any layout
+// CustomTime.Scan accepts. It is null if the variable is unset or empty.
+func CustomTimeFromEnv(key string) (CustomTime, error) {
+	var ct CustomTime
+	err := scanEnv(key, &ct)
+	return ct, err
+}
+
+// scanEnv scans the environment variable key into dst, leaving it untouched
+// if the variable is unset or empty.
+func scanEnv(key string, dst sql.Scanner) error {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return nil
+	}
+	if err := dst.Scan(v); err != nil {
+		return fmt.Errorf("environment variable %s: %w", key, err)
+	}
+	return nil
+}
+
+// FromEnv sets the fields of the struct dst points to from the environment
+// variables named by their `env` tags:
+//
+//	type Config struct {
+//		DSN     NullString `env:"DATABASE_URL"`
+//		Workers NullInt64  `env:"WORKERS"`
+//		Debug   NullBool   `env:"DEBUG"`
+//	}
+//
+// Fields whose variable is unset are left unchanged, so defaults set
+// beforehand survive. A NullString field set to "" becomes a valid empty
+// string; other octypes fields set to "" become null. Values are parsed by
+// the field's Scan method, so LocalizedText accepts JSON. Plain string,
+// integer and boolean fields are supported too, and untagged struct fields
+// are filled recursively.
+func FromEnv(dst interface{}) error {
+	rv, err := structPointerValue(dst)
+	if err != nil {
+		return err
+	}
+	return fillEnv(rv)
+}
+
+// fillEnv fills the addressable struct rv.
+func fillEnv(rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		key := f.Tag.Get("env")
+		if key == "" || key == "-" {
+			if key == "" && fv.Kind() == reflect.Struct && !isScanner(fv.Type()) {
+				if err := fillEnv(fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		v, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setEnvField(fv, v); err != nil {
+			return fmt.Errorf("environment variable %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// setEnvField sets fv from the variable value v.
+func setEnvField(fv reflect.Value, v string) error {
+	if s, ok := fv.Addr().Interface().(sql.Scanner); ok {
+		if _, isString := s.(*NullString); !isString && strings.TrimSpace(v) == "" {
+			fv.SetZero()
+			return nil
+		}
+		if r, ok := s.(interface{ Reset() }); ok {
+			r.Reset()
+		}
+		return s.Scan(v)
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// env_test.go
+package octypes
+
+import (
+	"testing"
+)
+
+func TestNullFromEnv(t *testing.T) {
+	t.Setenv("OCTYPES_TEST_EMPTY", "")
+	t.Setenv("OCTYPES_TEST_INT", "42")
+	t.Setenv("OCTYPES_TEST_BOOL", "true")
+	t.Setenv("OCTYPES_TEST_BAD", "x")
+
+	if ns := NullStringFromEnv("OCTYPES_TEST_UNSET"); ns.Valid {
+		t.Errorf("Expected null for unset variable, got %#v", ns)
+	}
+	if ns := NullStringFromEnv("OCTYPES_TEST_EMPTY"); !ns.Valid || ns.String != "" {
+		t.Errorf("Expected valid empty string, got %#v", ns)
+	}
+	if ni, err := NullInt64FromEnv("OCTYPES_TEST_INT"); err != nil || ni.Int64 != 42 {
+		t.Errorf("Expected 42, got %#v and %v", ni, err)
+	}
+	if ni, err := NullInt64FromEnv("OCTYPES_TEST_EMPTY"); err != nil || ni.Valid {
+		t.Errorf("Expected null for empty variable, got %#v and %v", ni, err)
+	}
+	if nb, err := NullBoolFromEnv("OCTYPES_TEST_BOOL"); err != nil || !nb.Bool {
+		t.Errorf("Expected true, got %#v and %v", nb, err)
+	}
+	if _, err := NullFloat64FromEnv("OCTYPES_TEST_BAD"); err == nil {
+		t.Errorf("Expected error for malformed float")
+	}
+	if ct, err := CustomTimeFromEnv("OCTYPES_TEST_UNSET"); err != nil || ct.Valid {
+		t.Errorf("Expected null time, got %#v and %v", ct, err)
+	}
+}
+
+type envConfig struct {
+	DSN     NullString    `env:"OCTYPES_TEST_DSN"`
+	Name    NullString    `env:"OCTYPES_TEST_EMPTY"`
+	Workers NullInt64     `env:"OCTYPES_TEST_WORKERS"`
+	Debug   NullBool      `env:"OCTYPES_TEST_DEBUG"`
+	Limit   NullInt64     `env:"OCTYPES_TEST_LIMIT"`
+	Title   LocalizedText `env:"OCTYPES_TEST_TITLE"`
+	Port    int           `env:"OCTYPES_TEST_PORT"`
+	Nested  struct {
+		Region string `env:"OCTYPES_TEST_REGION"`
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("OCTYPES_TEST_DSN", "postgres://")
+	t.Setenv("OCTYPES_TEST_EMPTY", "")
+	t.Setenv("OCTYPES_TEST_DEBUG", "")
+	t.Setenv("OCTYPES_TEST_LIMIT", "7")
+	t.Setenv("OCTYPES_TEST_TITLE", `{"en":"Shop"}`)
+	t.Setenv("OCTYPES_TEST_PORT", "8080")
+	t.Setenv("OCTYPES_TEST_REGION", "eu")
+
+	cfg := envConfig{Workers: *NewNullInt64(4), Debug: *NewNullBool(true)}
+	if err := FromEnv(&cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.DSN.String != "postgres://" || !cfg.Name.Valid || cfg.Limit.Int64 != 7 || cfg.Title["en"] != "Shop" {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+	if cfg.Workers.Int64 != 4 {
+		t.Errorf("Expected unset variable to keep the default, got %#v", cfg.Workers)
+	}
+	if cfg.Debug.Valid {
+		t.Errorf("Expected empty variable to give null, got %#v", cfg.Debug)
+	}
+	if cfg.Port != 8080 || cfg.Nested.Region != "eu" {
+		t.Errorf("Expected port 8080 and region eu, got %d and %q", cfg.Port, cfg.Nested.Region)
+	}
+
+	t.Setenv("OCTYPES_TEST_LIMIT", "many")
+	if err := FromEnv(&cfg); err == nil {
+		t.Errorf("Expected error for malformed integer")
+	}
+}
@@ -0,0 +1,96 @@
+// equal.go
+package octypes
+
+import (
+	"bytes"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Equal reports whether ns and other are both null or hold the same string.
+func (ns NullString) Equal(other NullString) bool {
+	return ns.Valid == other.Valid && (!ns.Valid || ns.String == other.String)
+}
+
+// Equal reports whether ni and other are both null or hold the same integer.
+func (ni NullInt64) Equal(other NullInt64) bool {
+	return ni.Valid == other.Valid && (!ni.Valid || ni.Int64 == other.Int64)
+}
+
+// Equal reports whether nb and other are both null or hold the same boolean.
+func (nb NullBool) Equal(other NullBool) bool {
+	return nb.Valid == other.Valid && (!nb.Valid || nb.Bool == other.Bool)
+}
+
+// Equal reports whether nf and other are both null or hold the same float.
+// As with ==, NaN is not equal to itself.
+func (nf NullFloat64) Equal(other NullFloat64) bool {
+	return nf.Valid == other.Valid && (!nf.Valid || nf.Float64 == other.Float64)
+}
+
+// Equal reports whether ct and other are both null or hold the same
+// instant, as time.Time.Equal, regardless of location.
+func (ct CustomTime) Equal(other CustomTime) bool {
+	return ct.Valid == other.Valid && (!ct.Valid || ct.Time.Equal(other.Time))
+}
+
+// Equal reports whether nb and other are both null or hold the same bytes.
+// The Encoding only affects the text representation and is ignored.
+func (nb NullBytes) Equal(other NullBytes) bool {
+	return nb.Valid == other.Valid && (!nb.Valid || bytes.Equal(nb.Bytes, other.Bytes))
+}
+
+// Equal reports whether c and other are both null or hold deeply equal
+// values, as reflect.DeepEqual.
+func (c Composite[T]) Equal(other Composite[T]) bool {
+	return c.Valid == other.Valid && (!c.Valid || reflect.DeepEqual(c.V, other.V))
+}
+
+// Equal reports whether lt and other are both null or hold the same
+// instant, as CustomTime.Equal. Tokens not parsed yet are parsed on every
+// call without keeping the result; call Get on both first to parse them
+// once, e.g. before comparing in a loop.
+func (lt LazyTime) Equal(other LazyTime) bool {
+	a, _ := lt.Get()
+	b, _ := other.Get()
+	return a.Equal(b)
+}
+
+// Equal reports whether lt and other are both nil or have the same entries.
+// A nil map, which is null, differs from an empty one.
+func (lt LocalizedText) Equal(other LocalizedText) bool {
+	return (lt == nil) == (other == nil) && maps.Equal(lt, other)
+}
+
+// Equal reports whether lh and other are both nil or have the same entries.
+func (lh LocalizedTextHstore) Equal(other LocalizedTextHstore) bool {
+	return (lh == nil) == (other == nil) && maps.Equal(lh, other)
+}
+
+// Equal reports whether id and other are both nil or have the same entries.
+func (id IntDictionary) Equal(other IntDictionary) bool {
+	return (id == nil) == (other == nil) && maps.Equal(id, other)
+}
+
+// Equal reports whether pt and other are both nil or have the same forms.
+func (pt PluralizedText) Equal(other PluralizedText) bool {
+	return (pt == nil) == (other == nil) && maps.EqualFunc(pt, other, maps.Equal)
+}
+
+// Equal reports whether lc and other are both nil or have the same entries.
+func (lc LocalizedContent) Equal(other LocalizedContent) bool {
+	return (lc == nil) == (other == nil) && maps.Equal(lc, other)
+}
+
+// Equal reports whether a and other are both nil or have equal elements in
+// the same order.
+func (a NullStringArray) Equal(other NullStringArray) bool {
+	return (a == nil) == (other == nil) && slices.EqualFunc(a, other, NullString.Equal)
+}
+
+// Equal reports whether a and other are both nil or have equal elements in
+// the same order.
+func (a NullInt64Array) Equal(other NullInt64Array) bool {
+	return (a == nil) == (other == nil) && slices.EqualFunc(a, other, NullInt64.Equal)
+}
@@ -0,0 +1,45 @@
+// equal_test.go
+package octypes
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"NullString nulls", NullString{}.Equal(NullString{}), true},
+		{"NullString null vs empty", NullString{}.Equal(*NewNullStringFromPtr(new(string))), false},
+		{"NullInt64", NewNullInt64(1).Equal(*NewNullInt64(1)), true},
+		{"NullInt64 null vs zero", NullInt64{}.Equal(*NewNullInt64(0)), false},
+		{"NullBool", NewNullBool(true).Equal(*NewNullBool(false)), false},
+		{"NullFloat64 NaN", NewNullFloat64(math.NaN()).Equal(*NewNullFloat64(math.NaN())), false},
+		{"CustomTime locations", NewCustomTime(time.Unix(100, 0).UTC()).Equal(*NewCustomTime(time.Unix(100, 0).In(time.FixedZone("X", 3600)))), true},
+		{"NullBytes encoding", NewNullBytesWithEncoding([]byte("a"), BytesEncodingHex).Equal(*NewNullBytes([]byte("a"))), true},
+		{"Composite", NewComposite([]int{1}).Equal(*NewComposite([]int{1})), true},
+		{"LocalizedText nil vs empty", LocalizedText(nil).Equal(LocalizedText{}), false},
+		{"LocalizedText", LocalizedText{"en": "a"}.Equal(LocalizedText{"en": "a"}), true},
+		{"LocalizedText differs", LocalizedText{"en": "a"}.Equal(LocalizedText{"en": "b"}), false},
+		{"IntDictionary", IntDictionary{"a": 1}.Equal(IntDictionary{"a": 1}), true},
+		{"PluralizedText", PluralizedText{"en": {PluralOne: "x"}}.Equal(PluralizedText{"en": {PluralOne: "y"}}), false},
+		{"LocalizedContent", LocalizedContent{"en": {Text: "a"}}.Equal(LocalizedContent{"en": {Text: "a"}}), true},
+		{"NullStringArray", NullStringArray{{}, *NewNullString("a")}.Equal(NullStringArray{{}, *NewNullString("a")}), true},
+		{"NullInt64Array order", NullInt64Array{*NewNullInt64(1), *NewNullInt64(2)}.Equal(NullInt64Array{*NewNullInt64(2), *NewNullInt64(1)}), false},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+
+	stale := NullString{}
+	stale.String = "leftover"
+	if !stale.Equal(NullString{}) {
+		t.Errorf("Expected null values to be equal regardless of their payload")
+	}
+}
@@ -0,0 +1,150 @@
+// Package fake generates realistic random octypes values for test fixtures
+// and demo data. A Faker seeded with the same value produces the same
+// sequence of values:
+//
+//	f := fake.New(42)
+//	user := User{
+//		Name:      f.Name(),
+//		Email:     f.Email(),
+//		CreatedAt: f.Recent(30 * 24 * time.Hour),
+//		Title:     f.LocalizedText("en", "fr", "de"),
+//	}
+package fake
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coffyg/octypes"
+)
+
+// Faker generates random values. It is not safe for concurrent use.
+type Faker struct {
+	r *rand.Rand
+	// Now is the reference time of Recent and Soon. It defaults to the
+	// time New was called; set it to make times reproducible.
+	Now time.Time
+	// NullRatio is the probability, between 0 and 1, that a generated value
+	// is null. It defaults to 0.
+	NullRatio float64
+}
+
+// New returns a Faker seeded with seed.
+func New(seed int64) *Faker {
+	return &Faker{r: rand.New(rand.NewSource(seed)), Now: time.Now()}
+}
+
+// null reports whether the next value should be null.
+func (f *Faker) null() bool {
+	return f.NullRatio > 0 && f.r.Float64() < f.NullRatio
+}
+
+// pick returns a random element of s.
+func pick[T any](f *Faker, s []T) T {
+	return s[f.r.Intn(len(s))]
+}
+
+// String returns a valid NullString holding s unless the value is null.
+func (f *Faker) String(s string) octypes.NullString {
+	if f.null() {
+		return octypes.NullString{}
+	}
+	return *octypes.NewNullStringFromPtr(&s)
+}
+
+// FirstName returns a first name.
+func (f *Faker) FirstName() octypes.NullString {
+	return f.String(pick(f, firstNames))
+}
+
+// LastName returns a last name.
+func (f *Faker) LastName() octypes.NullString {
+	return f.String(pick(f, lastNames))
+}
+
+// Name returns a first and last name.
+func (f *Faker) Name() octypes.NullString {
+	return f.String(pick(f, firstNames) + " " + pick(f, lastNames))
+}
+
+// Email returns an email address at an example domain.
+func (f *Faker) Email() octypes.NullString {
+	local := strings.ToLower(pick(f, firstNames) + "." + pick(f, lastNames))
+	return f.String(local + strconv.Itoa(f.r.Intn(100)) + "@" + pick(f, domains))
+}
+
+// Sentence returns a sentence of a few English words.
+func (f *Faker) Sentence() octypes.NullString {
+	words := make([]string, 4+f.r.Intn(8))
+	for i := range words {
+		words[i] = pick(f, loremWords)
+	}
+	s := strings.Join(words, " ")
+	return f.String(strings.ToUpper(s[:1]) + s[1:] + ".")
+}
+
+// Int64 returns an integer in [min, max].
+func (f *Faker) Int64(min, max int64) octypes.NullInt64 {
+	if f.null() {
+		return octypes.NullInt64{}
+	}
+	return *octypes.NewNullInt64(min + f.r.Int63n(max-min+1))
+}
+
+// Float64 returns a float in [min, max).
+func (f *Faker) Float64(min, max float64) octypes.NullFloat64 {
+	if f.null() {
+		return octypes.NullFloat64{}
+	}
+	return *octypes.NewNullFloat64(min + f.r.Float64()*(max-min))
+}
+
+// Bool returns true or false with equal probability.
+func (f *Faker) Bool() octypes.NullBool {
+	if f.null() {
+		return octypes.NullBool{}
+	}
+	return *octypes.NewNullBool(f.r.Intn(2) == 0)
+}
+
+// Between returns a time in [from, to), truncated to the microsecond as
+// databases store it.
+func (f *Faker) Between(from, to time.Time) octypes.CustomTime {
+	if f.null() || !to.After(from) {
+		return *octypes.NewCustomTimeNull()
+	}
+	d := time.Duration(f.r.Int63n(int64(to.Sub(from))))
+	return *octypes.NewCustomTime(from.Add(d).Truncate(time.Microsecond))
+}
+
+// Recent returns a time within d before Now.
+func (f *Faker) Recent(d time.Duration) octypes.CustomTime {
+	return f.Between(f.Now.Add(-d), f.Now)
+}
+
+// Soon returns a time within d after Now.
+func (f *Faker) Soon(d time.Duration) octypes.CustomTime {
+	return f.Between(f.Now, f.Now.Add(d))
+}
+
+// LocalizedText returns a product name, such as "Lamp 240", translated
+// into each of locales. Locales without a translation table get the
+// English text. With no locales, English is used.
+func (f *Faker) LocalizedText(locales ...string) octypes.LocalizedText {
+	if f.null() {
+		return nil
+	}
+	if len(locales) == 0 {
+		locales = []string{"en"}
+	}
+	noun := pick(f, products)
+	model := " " + strconv.Itoa(100+f.r.Intn(900))
+	lt := make(octypes.LocalizedText, len(locales))
+	for _, locale := range locales {
+		lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+		lt[locale] = noun[languages[lang]] + model
+	}
+	return lt
+}
@@ -0,0 +1,64 @@
+// fake_test.go
+package fake
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeterministic(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	a, b := New(7), New(7)
+	a.Now, b.Now = now, now
+	for i := 0; i < 20; i++ {
+		if x, y := a.Name(), b.Name(); x != y {
+			t.Errorf("Expected equal names, got %v and %v", x, y)
+		}
+		if x, y := a.Recent(time.Hour), b.Recent(time.Hour); !x.Equal(y) {
+			t.Errorf("Expected equal times, got %v and %v", x, y)
+		}
+		if x, y := a.LocalizedText("en", "fr"), b.LocalizedText("en", "fr"); !x.Equal(y) {
+			t.Errorf("Expected equal texts, got %v and %v", x, y)
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	f := New(1)
+	f.Now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if name := f.Name(); !name.Valid || !strings.Contains(name.String, " ") {
+		t.Errorf("Expected a full name, got %v", name)
+	}
+	if email := f.Email(); !strings.Contains(email.String, "@example.") {
+		t.Errorf("Expected an example email, got %v", email)
+	}
+	if s := f.Sentence(); !strings.HasSuffix(s.String, ".") {
+		t.Errorf("Expected a sentence, got %v", s)
+	}
+	if n := f.Int64(5, 5); n.Int64 != 5 {
+		t.Errorf("Expected 5, got %v", n)
+	}
+	ct := f.Recent(24 * time.Hour)
+	if !ct.Valid || ct.Time.After(f.Now) || ct.Time.Before(f.Now.Add(-24*time.Hour)) {
+		t.Errorf("Expected a time within the last day, got %v", ct)
+	}
+	if ct := f.Soon(time.Hour); !ct.Time.After(f.Now.Add(-time.Microsecond)) {
+		t.Errorf("Expected a future time, got %v", ct)
+	}
+
+	lt := f.LocalizedText("en", "fr-CA", "xx")
+	en, fr := strings.Fields(lt["en"]), strings.Fields(lt["fr-CA"])
+	if len(en) != 2 || len(fr) != 2 || en[1] != fr[1] || lt["xx"] != lt["en"] {
+		t.Errorf("Expected matching translations, got %v", lt)
+	}
+}
+
+func TestNullRatio(t *testing.T) {
+	f := New(1)
+	f.NullRatio = 1
+	if f.Name().Valid || f.Bool().Valid || f.Recent(time.Hour).Valid || f.LocalizedText() != nil {
+		t.Errorf("Expected only null values")
+	}
+}
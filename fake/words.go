@@ -0,0 +1,38 @@
+// words.go
+package fake
+
+var firstNames = []string{
+	"Alice", "Amara", "Ben", "Carlos", "Chloé", "David", "Elena", "Fatima",
+	"Grace", "Hiro", "Ines", "Jonas", "Kenji", "Laura", "Lucas", "Maya",
+	"Noah", "Olivia", "Pedro", "Priya", "Sofia", "Tom", "Yara", "Zoe",
+}
+
+var lastNames = []string{
+	"Andersen", "Bernard", "Chen", "Dubois", "Fernandes", "García", "Hansen",
+	"Ivanova", "Johnson", "Kim", "Lefebvre", "Martin", "Müller", "Nakamura",
+	"Okafor", "Patel", "Rossi", "Schmidt", "Silva", "Smith", "Tanaka", "Weber",
+}
+
+var domains = []string{"example.com", "example.org", "example.net"}
+
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing",
+	"elit", "sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore",
+	"et", "dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam",
+}
+
+// languages maps a primary language subtag to its column in products.
+// Other languages get column 0, English.
+var languages = map[string]int{"en": 0, "fr": 1, "de": 2, "es": 3, "it": 4, "pt": 5}
+
+// products holds product names in the order of languages.
+var products = [][6]string{
+	{"Chair", "Chaise", "Stuhl", "Silla", "Sedia", "Cadeira"},
+	{"Table", "Table", "Tisch", "Mesa", "Tavolo", "Mesa"},
+	{"Lamp", "Lampe", "Lampe", "Lámpara", "Lampada", "Candeeiro"},
+	{"Wardrobe", "Armoire", "Kleiderschrank", "Armario", "Armadio", "Guarda-roupa"},
+	{"Bookcase", "Bibliothèque", "Bücherregal", "Estantería", "Libreria", "Estante"},
+	{"Sofa", "Canapé", "Sofa", "Sofá", "Divano", "Sofá"},
+	{"Mirror", "Miroir", "Spiegel", "Espejo", "Specchio", "Espelho"},
+	{"Rug", "Tapis", "Teppich", "Alfombra", "Tappeto", "Tapete"},
+}
@@ -0,0 +1,71 @@
+// fields.go
+package octypes
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// dbField is a struct field mapped to a column by its `db` tag.
+// nullPolicy is its `ocnull` tag, applied by Args, NamedArgs and ScanRow.
+type dbField struct {
+	name       string
+	index      []int
+	nullPolicy string
+}
+
+var dbFieldsCache sync.Map // map[reflect.Type][]dbField
+
+// dbFields returns the `db` tagged fields of struct type t in declaration
+// order. Fields of embedded structs are included; fields tagged "-" and
+// fields without a tag are skipped.
+func dbFields(t reflect.Type) []dbField {
+	if cached, ok := dbFieldsCache.Load(t); ok {
+		return cached.([]dbField)
+	}
+	fields := appendDBFields(nil, t, nil)
+	dbFieldsCache.Store(t, fields)
+	return fields
+}
+
+func appendDBFields(fields []dbField, t reflect.Type, parent []int) []dbField {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		tag, hasTag := f.Tag.Lookup("db")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct && !isScanner(f.Type) {
+			fields = appendDBFields(fields, f.Type, index)
+			continue
+		}
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		fields = append(fields, dbField{name: name, index: index, nullPolicy: f.Tag.Get("ocnull")})
+	}
+	return fields
+}
+
+var scannerType = reflect.TypeOf((*interface{ Scan(interface{}) error })(nil)).Elem()
+
+// isScanner reports whether a pointer to t implements sql.Scanner. Such
+// embedded structs are values in their own right, not field groups.
+func isScanner(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(scannerType)
+}
+
+var errNotStructPointer = errors.New("destination must be a non-nil pointer to a struct")
+
+// structPointerValue returns the struct v points to.
+func structPointerValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, errNotStructPointer
+	}
+	return rv.Elem(), nil
+}
@@ -0,0 +1,258 @@
+// generate.go
+package octypes
+
+import (
+	"database/sql"
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// The Generate methods implement testing/quick.Generator, so that
+// quick.Check can drive properties over octypes values. About one value in
+// five is null, and about one valid value in four is an edge case such as
+// an empty string, the integer limits, NaN, the infinities or the zero
+// time. Composite needs no Generate method, as quick generates structs
+// with exported fields itself.
+
+// genEdgeOdds is the inverse probability of picking an edge case.
+const genEdgeOdds = 4
+
+// genNull reports whether to generate a null value.
+func genNull(r *rand.Rand) bool {
+	return r.Intn(5) == 0
+}
+
+// genEdgeStrings are the string edge cases.
+var genEdgeStrings = []string{"", " ", `"`, `\`, "NULL", "null", "é", "日本語", " ", "a\nb", "{}", "=>"}
+
+// genString returns a string of at most size runes.
+func genString(r *rand.Rand, size int) string {
+	if r.Intn(genEdgeOdds) == 0 {
+		return genEdgeStrings[r.Intn(len(genEdgeStrings))]
+	}
+	runes := make([]rune, r.Intn(size+1))
+	for i := range runes {
+		if r.Intn(4) == 0 {
+			runes[i] = rune(0x80 + r.Intn(0xd800-0x80))
+		} else {
+			runes[i] = rune(0x20 + r.Intn(0x7f-0x20))
+		}
+	}
+	return string(runes)
+}
+
+// genEdgeInts are the integer edge cases.
+var genEdgeInts = []int64{0, 1, -1, math.MinInt64, math.MaxInt64, math.MinInt32, math.MaxInt32, 1 << 53, -(1 << 53)}
+
+// genInt64 returns an integer spread over the whole int64 range.
+func genInt64(r *rand.Rand) int64 {
+	if r.Intn(genEdgeOdds) == 0 {
+		return genEdgeInts[r.Intn(len(genEdgeInts))]
+	}
+	n := r.Int63() >> r.Intn(63)
+	if r.Intn(2) == 0 {
+		n = -n
+	}
+	return n
+}
+
+// genEdgeFloats are the float edge cases.
+var genEdgeFloats = []float64{0, math.Copysign(0, -1), math.NaN(), math.Inf(1), math.Inf(-1), math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64, 0.1}
+
+// genFloat64 returns a float of widely varying magnitude.
+func genFloat64(r *rand.Rand) float64 {
+	if r.Intn(genEdgeOdds) == 0 {
+		return genEdgeFloats[r.Intn(len(genEdgeFloats))]
+	}
+	return r.NormFloat64() * math.Pow(10, float64(r.Intn(40)-20))
+}
+
+// genEdgeTimes are the time edge cases: the zero time, the Unix and
+// Postgres epochs, and the last instant of year 9999.
+var genEdgeTimes = []time.Time{
+	{},
+	time.Unix(0, 0).UTC(),
+	postgresEpoch,
+	time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+}
+
+// genTimeSpan is the number of seconds from year 1 to the end of year 9999.
+var genTimeSpan = genEdgeTimes[3].Unix() - genEdgeTimes[0].Unix()
+
+// genTime returns a UTC time within years 1 to 9999.
+func genTime(r *rand.Rand) time.Time {
+	if r.Intn(genEdgeOdds) == 0 {
+		return genEdgeTimes[r.Intn(len(genEdgeTimes))]
+	}
+	sec := genEdgeTimes[0].Unix() + r.Int63n(genTimeSpan)
+	return time.Unix(sec, r.Int63n(1e9)).UTC()
+}
+
+// genLanguageTags are the keys of generated maps.
+var genLanguageTags = []string{"en", "fr", "de", "en-US", "pt-BR", "zh-Hant"}
+
+// genKey returns a map key, usually a language tag.
+func genKey(r *rand.Rand, size int) string {
+	if r.Intn(genEdgeOdds) == 0 {
+		return genString(r, size)
+	}
+	return genLanguageTags[r.Intn(len(genLanguageTags))]
+}
+
+// genLen returns the length of a generated map or array.
+func genLen(r *rand.Rand, size int) int {
+	return r.Intn(min(size, 8) + 1)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullString) Generate(r *rand.Rand, size int) reflect.Value {
+	var ns NullString
+	if !genNull(r) {
+		ns.NullString = sql.NullString{String: genString(r, size), Valid: true}
+	}
+	return reflect.ValueOf(ns)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullInt64) Generate(r *rand.Rand, size int) reflect.Value {
+	var ni NullInt64
+	if !genNull(r) {
+		ni.NullInt64 = sql.NullInt64{Int64: genInt64(r), Valid: true}
+	}
+	return reflect.ValueOf(ni)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullBool) Generate(r *rand.Rand, size int) reflect.Value {
+	var nb NullBool
+	if !genNull(r) {
+		nb.NullBool = sql.NullBool{Bool: r.Intn(2) == 0, Valid: true}
+	}
+	return reflect.ValueOf(nb)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullFloat64) Generate(r *rand.Rand, size int) reflect.Value {
+	var nf NullFloat64
+	if !genNull(r) {
+		nf.NullFloat64 = sql.NullFloat64{Float64: genFloat64(r), Valid: true}
+	}
+	return reflect.ValueOf(nf)
+}
+
+// Generate implements the quick.Generator interface.
+func (CustomTime) Generate(r *rand.Rand, size int) reflect.Value {
+	var ct CustomTime
+	if !genNull(r) {
+		ct.NullTime = sql.NullTime{Time: genTime(r), Valid: true}
+	}
+	return reflect.ValueOf(ct)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullBytes) Generate(r *rand.Rand, size int) reflect.Value {
+	var nb NullBytes
+	if !genNull(r) {
+		nb.Bytes = make([]byte, r.Intn(size+1))
+		r.Read(nb.Bytes)
+		nb.Valid = true
+	}
+	return reflect.ValueOf(nb)
+}
+
+// genStringMap returns a nil or populated map of strings.
+func genStringMap(r *rand.Rand, size int) map[string]string {
+	if genNull(r) {
+		return nil
+	}
+	m := make(map[string]string)
+	for n := genLen(r, size); n > 0; n-- {
+		m[genKey(r, size)] = genString(r, size)
+	}
+	return m
+}
+
+// Generate implements the quick.Generator interface.
+func (LocalizedText) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(LocalizedText(genStringMap(r, size)))
+}
+
+// Generate implements the quick.Generator interface.
+func (LocalizedTextHstore) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(LocalizedTextHstore(genStringMap(r, size)))
+}
+
+// Generate implements the quick.Generator interface.
+func (IntDictionary) Generate(r *rand.Rand, size int) reflect.Value {
+	if genNull(r) {
+		return reflect.ValueOf(IntDictionary(nil))
+	}
+	id := make(IntDictionary)
+	for n := genLen(r, size); n > 0; n-- {
+		id[genString(r, size)] = int(genInt64(r))
+	}
+	return reflect.ValueOf(id)
+}
+
+// genPluralCategories are the categories of generated PluralizedText.
+var genPluralCategories = []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// Generate implements the quick.Generator interface.
+func (PluralizedText) Generate(r *rand.Rand, size int) reflect.Value {
+	if genNull(r) {
+		return reflect.ValueOf(PluralizedText(nil))
+	}
+	pt := make(PluralizedText)
+	for n := genLen(r, size); n > 0; n-- {
+		forms := make(map[PluralCategory]string)
+		for m := genLen(r, size); m > 0; m-- {
+			forms[genPluralCategories[r.Intn(len(genPluralCategories))]] = genString(r, size)
+		}
+		pt[genKey(r, size)] = forms
+	}
+	return reflect.ValueOf(pt)
+}
+
+// genContentFormats are the formats of generated Content.
+var genContentFormats = []ContentFormat{ContentPlain, ContentMarkdown, ContentHTML}
+
+// Generate implements the quick.Generator interface.
+func (LocalizedContent) Generate(r *rand.Rand, size int) reflect.Value {
+	if genNull(r) {
+		return reflect.ValueOf(LocalizedContent(nil))
+	}
+	lc := make(LocalizedContent)
+	for n := genLen(r, size); n > 0; n-- {
+		lc[genKey(r, size)] = Content{
+			Text:   genString(r, size),
+			Format: genContentFormats[r.Intn(len(genContentFormats))],
+		}
+	}
+	return reflect.ValueOf(lc)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullStringArray) Generate(r *rand.Rand, size int) reflect.Value {
+	if genNull(r) {
+		return reflect.ValueOf(NullStringArray(nil))
+	}
+	a := make(NullStringArray, genLen(r, size))
+	for i := range a {
+		a[i] = NullString{}.Generate(r, size).Interface().(NullString)
+	}
+	return reflect.ValueOf(a)
+}
+
+// Generate implements the quick.Generator interface.
+func (NullInt64Array) Generate(r *rand.Rand, size int) reflect.Value {
+	if genNull(r) {
+		return reflect.ValueOf(NullInt64Array(nil))
+	}
+	a := make(NullInt64Array, genLen(r, size))
+	for i := range a {
+		a[i] = NullInt64{}.Generate(r, size).Interface().(NullInt64)
+	}
+	return reflect.ValueOf(a)
+}
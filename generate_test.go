@@ -0,0 +1,65 @@
+// generate_test.go
+package octypes
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestGenerate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	types := []Nullable{
+		NullString{}, NullInt64{}, NullBool{}, NullFloat64{}, CustomTime{}, NullBytes{},
+		LocalizedText{}, LocalizedTextHstore{}, IntDictionary{}, PluralizedText{},
+		LocalizedContent{}, NullStringArray{}, NullInt64Array{}, Composite[int]{},
+	}
+	for _, typ := range types {
+		var nulls, valid int
+		for i := 0; i < 200; i++ {
+			v, ok := quick.Value(reflect.TypeOf(typ), r)
+			if !ok {
+				t.Fatalf("%T: Expected a generated value", typ)
+			}
+			if v.Interface().(Nullable).IsNull() {
+				nulls++
+			} else {
+				valid++
+			}
+		}
+		if nulls == 0 || valid == 0 {
+			t.Errorf("%T: Expected both null and valid values, got %d and %d", typ, nulls, valid)
+		}
+	}
+}
+
+func TestGenerateEdgeCases(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var emptyString, maxInt, zeroTime bool
+	for i := 0; i < 1000; i++ {
+		ns := NullString{}.Generate(r, 10).Interface().(NullString)
+		emptyString = emptyString || (ns.Valid && ns.String == "")
+		ni := NullInt64{}.Generate(r, 10).Interface().(NullInt64)
+		maxInt = maxInt || ni.Int64 == genEdgeInts[4]
+		ct := CustomTime{}.Generate(r, 10).Interface().(CustomTime)
+		zeroTime = zeroTime || (ct.Valid && ct.Time.IsZero())
+	}
+	if !emptyString || !maxInt || !zeroTime {
+		t.Errorf("Expected edge cases, got empty string %v, max int %v, zero time %v", emptyString, maxInt, zeroTime)
+	}
+}
+
+func TestQuickCheckRoundTrip(t *testing.T) {
+	f := func(ni NullInt64) bool {
+		v, err := ni.Value()
+		if err != nil {
+			return false
+		}
+		var got NullInt64
+		return got.Scan(v) == nil && got.Equal(ni)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
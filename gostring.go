@@ -0,0 +1,210 @@
+// gostring.go
+package octypes
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The GoString methods make %#v print octypes values as the Go expressions
+// that build them, e.g. *octypes.NewNullInt64(42) or octypes.NullString{},
+// instead of the nested fields of the embedded database/sql types.
+
+// GoString implements the fmt.GoStringer interface.
+func (ns NullString) GoString() string {
+	switch {
+	case !ns.Valid:
+		return "octypes.NullString{}"
+	case ns.String == "":
+		return `*octypes.NewNullStringAllowEmpty("")`
+	}
+	return "*octypes.NewNullString(" + strconv.Quote(ns.String) + ")"
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ni NullInt64) GoString() string {
+	if !ni.Valid {
+		return "octypes.NullInt64{}"
+	}
+	return "*octypes.NewNullInt64(" + strconv.FormatInt(ni.Int64, 10) + ")"
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (nb NullBool) GoString() string {
+	if !nb.Valid {
+		return "octypes.NullBool{}"
+	}
+	return "*octypes.NewNullBool(" + strconv.FormatBool(nb.Bool) + ")"
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (nf NullFloat64) GoString() string {
+	if !nf.Valid {
+		return "octypes.NullFloat64{}"
+	}
+	return "*octypes.NewNullFloat64(" + goFloat(nf.Float64) + ")"
+}
+
+// goFloat returns f as a Go expression.
+func goFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ct CustomTime) GoString() string {
+	if !ct.Valid {
+		return "octypes.CustomTime{}"
+	}
+	return "*octypes.NewCustomTime(" + goTime(ct.Time) + ")"
+}
+
+// GoString implements the fmt.GoStringer interface. Tokens not parsed yet
+// are parsed without keeping the result.
+func (lt LazyTime) GoString() string {
+	ct, _ := lt.Get()
+	if !ct.Valid {
+		return "octypes.LazyTime{}"
+	}
+	return "octypes.NewLazyTime(" + ct.GoString() + ")"
+}
+
+// goTime returns t as a time.Date expression.
+func goTime(t time.Time) string {
+	var loc string
+	switch t.Location() {
+	case time.UTC:
+		loc = "time.UTC"
+	case time.Local:
+		loc = "time.Local"
+	default:
+		name, offset := t.Zone()
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (nb NullBytes) GoString() string {
+	if !nb.Valid {
+		if nb.Encoding != BytesEncodingDefault {
+			return "octypes.NullBytes{Encoding: " + goBytesEncoding(nb.Encoding) + "}"
+		}
+		return "octypes.NullBytes{}"
+	}
+	b := "[]byte(" + strconv.Quote(string(nb.Bytes)) + ")"
+	if nb.Encoding != BytesEncodingDefault {
+		return "*octypes.NewNullBytesWithEncoding(" + b + ", " + goBytesEncoding(nb.Encoding) + ")"
+	}
+	return "*octypes.NewNullBytes(" + b + ")"
+}
+
+// goBytesEncoding returns the name of the constant e.
+func goBytesEncoding(e BytesEncoding) string {
+	switch e {
+	case BytesEncodingBase64:
+		return "octypes.BytesEncodingBase64"
+	case BytesEncodingBase64URL:
+		return "octypes.BytesEncodingBase64URL"
+	case BytesEncodingHex:
+		return "octypes.BytesEncodingHex"
+	}
+	return fmt.Sprintf("octypes.BytesEncoding(%d)", uint8(e))
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (c Composite[T]) GoString() string {
+	if !c.Valid {
+		return fmt.Sprintf("octypes.Composite[%T]{}", c.V)
+	}
+	return fmt.Sprintf("*octypes.NewComposite(%#v)", c.V)
+}
+
+// goMap formats a map literal of type name with sorted keys, or a nil
+// conversion if m is nil.
+func goMap[K ~string, V any](name string, m map[K]V, value func(V) string) string {
+	if m == nil {
+		return name + "(nil)"
+	}
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, k := range slices.Sorted(maps.Keys(m)) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(string(k)))
+		b.WriteString(": ")
+		b.WriteString(value(m[k]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (lt LocalizedText) GoString() string {
+	return goMap("octypes.LocalizedText", lt, strconv.Quote)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (lh LocalizedTextHstore) GoString() string {
+	return goMap("octypes.LocalizedTextHstore", lh, strconv.Quote)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (id IntDictionary) GoString() string {
+	return goMap("octypes.IntDictionary", id, strconv.Itoa)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (pt PluralizedText) GoString() string {
+	return goMap("octypes.PluralizedText", pt, func(forms map[PluralCategory]string) string {
+		if forms == nil {
+			return "nil"
+		}
+		return goMap("", forms, strconv.Quote)
+	})
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (lc LocalizedContent) GoString() string {
+	return goMap("octypes.LocalizedContent", lc, func(c Content) string {
+		return fmt.Sprintf("{Text: %q, Format: %q}", c.Text, c.Format)
+	})
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (a NullStringArray) GoString() string {
+	return goSlice("octypes.NullStringArray", a)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (a NullInt64Array) GoString() string {
+	return goSlice("octypes.NullInt64Array", a)
+}
+
+// goSlice formats a slice literal of type name, or a nil conversion if s
+// is nil.
+func goSlice[E fmt.GoStringer](name string, s []E) string {
+	if s == nil {
+		return name + "(nil)"
+	}
+	elems := make([]string, len(s))
+	for i, e := range s {
+		elems[i] = e.GoString()
+	}
+	return name + "{" + strings.Join(elems, ", ") + "}"
+}
@@ -0,0 +1,43 @@
+// gostring_test.go
+package octypes
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGoString(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{NullString{}, `octypes.NullString{}`},
+		{*NewNullString("a\"b"), `*octypes.NewNullString("a\"b")`},
+		{*NewNullStringAllowEmpty(""), `*octypes.NewNullStringAllowEmpty("")`},
+		{*NewNullInt64(42), `*octypes.NewNullInt64(42)`},
+		{NullInt64{}, `octypes.NullInt64{}`},
+		{*NewNullBool(true), `*octypes.NewNullBool(true)`},
+		{*NewNullFloat64(1.5), `*octypes.NewNullFloat64(1.5)`},
+		{*NewNullFloat64(math.Inf(-1)), `*octypes.NewNullFloat64(math.Inf(-1))`},
+		{*NewCustomTime(time.Date(2024, 3, 4, 5, 6, 7, 8, time.UTC)), `*octypes.NewCustomTime(time.Date(2024, time.March, 4, 5, 6, 7, 8, time.UTC))`},
+		{*NewCustomTime(time.Date(2024, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))), `*octypes.NewCustomTime(time.Date(2024, time.March, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600)))`},
+		{*NewNullBytes([]byte("hi")), `*octypes.NewNullBytes([]byte("hi"))`},
+		{*NewNullBytesWithEncoding([]byte("hi"), BytesEncodingHex), `*octypes.NewNullBytesWithEncoding([]byte("hi"), octypes.BytesEncodingHex)`},
+		{Composite[int]{}, `octypes.Composite[int]{}`},
+		{*NewComposite(7), `*octypes.NewComposite(7)`},
+		{LocalizedText{"fr": "b", "en": "a"}, `octypes.LocalizedText{"en": "a", "fr": "b"}`},
+		{LocalizedText(nil), `octypes.LocalizedText(nil)`},
+		{IntDictionary{"a": 1}, `octypes.IntDictionary{"a": 1}`},
+		{PluralizedText{"en": {PluralOne: "x"}}, `octypes.PluralizedText{"en": {"one": "x"}}`},
+		{LocalizedContent{"en": {Text: "a", Format: ContentMarkdown}}, `octypes.LocalizedContent{"en": {Text: "a", Format: "markdown"}}`},
+		{NullStringArray{{}, *NewNullString("a")}, `octypes.NullStringArray{octypes.NullString{}, *octypes.NewNullString("a")}`},
+		{NullInt64Array(nil), `octypes.NullInt64Array(nil)`},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%#v", tt.v); got != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// hstore.go
+package octypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LocalizedTextHstore is a LocalizedText stored in a Postgres hstore
+// column. Scan accepts both hstore and JSON text, and Value produces hstore
+// text, so columns can be migrated between the two gradually. hstore NULL
+// values have no LocalizedText representation and are dropped.
+type LocalizedTextHstore LocalizedText
+
+// Scan implements the sql.Scanner interface.
+func (lh *LocalizedTextHstore) Scan(value interface{}) error {
+	return (*LocalizedText)(lh).Scan(value)
+}
+
+// Value implements the driver.Valuer interface.
+func (lh LocalizedTextHstore) Value() (driver.Value, error) {
+	if lh == nil {
+		return nil, nil
+	}
+	return FormatHstore(lh), nil
+}
+
+// scanHstore replaces lt with the non-NULL pairs of hstore text s.
+func (lt *LocalizedText) scanHstore(s string) error {
+	pairs, err := ParseHstore(s)
+	if err != nil {
+		return err
+	}
+	m := make(map[string]string, len(pairs))
+	for k, v := range pairs {
+		if v.Valid {
+			m[k] = v.String
+		}
+	}
+	return lt.set(m)
+}
+
+// isHstoreText reports whether a LocalizedText Scan source is hstore text
+// rather than a JSON object. JSON objects start with '{' while hstore text
+// starts with a key. Empty input is left to the JSON decoder, which rejects
+// it, rather than being read as an empty hstore.
+func isHstoreText(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '"'
+}
+
+// ParseHstore parses Postgres hstore text such as "a"=>"1", "b"=>NULL.
+func ParseHstore(src string) (map[string]NullString, error) {
+	pairs := make(map[string]NullString)
+	s := src
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+		if s == "" {
+			return pairs, nil
+		}
+
+		key, quoted, rest, err := parseHstoreToken(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hstore %q: %w", src, err)
+		}
+		if !quoted && strings.EqualFold(key, "NULL") {
+			return nil, fmt.Errorf("invalid hstore %q: NULL key", src)
+		}
+		rest = strings.TrimLeft(rest, " \t\n\r")
+		if !strings.HasPrefix(rest, "=>") {
+			return nil, fmt.Errorf("invalid hstore %q: expected '=>'", src)
+		}
+		rest = strings.TrimLeft(rest[2:], " \t\n\r")
+
+		value, quoted, rest, err := parseHstoreToken(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hstore %q: %w", src, err)
+		}
+		if !quoted && strings.EqualFold(value, "NULL") {
+			pairs[key] = NullString{}
+		} else {
+			pairs[key] = *NewNullStringAllowEmpty(value)
+		}
+
+		s = strings.TrimLeft(rest, " \t\n\r")
+		if s == "" {
+			return pairs, nil
+		}
+		if s[0] != ',' {
+			return nil, fmt.Errorf("invalid hstore %q: expected ','", src)
+		}
+		s = s[1:]
+	}
+}
+
+// parseHstoreToken parses a quoted or bare hstore key or value from the
+// start of s and returns the remaining input.
+func parseHstoreToken(s string) (token string, quoted bool, rest string, err error) {
+	var buf strings.Builder
+	if s != "" && s[0] == '"' {
+		for i := 1; i < len(s); i++ {
+			switch c := s[i]; c {
+			case '\\':
+				if i+1 < len(s) {
+					i++
+					buf.WriteByte(s[i])
+				}
+			case '"':
+				return buf.String(), true, s[i+1:], nil
+			default:
+				buf.WriteByte(c)
+			}
+		}
+		return "", false, "", fmt.Errorf("unterminated quote")
+	}
+
+	i := 0
+	for i < len(s) && !strings.ContainsRune(" \t\n\r,=>\"", rune(s[i])) {
+		i++
+	}
+	if i == 0 {
+		return "", false, "", fmt.Errorf("missing key or value")
+	}
+	return s[:i], false, s[i:], nil
+}
+
+// FormatHstore formats m as Postgres hstore text with keys in sorted order.
+func FormatHstore[M ~map[string]string](m M) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := defaultBufferPool.Get(0)
+	buf := *b
+	for i, k := range keys {
+		if i > 0 {
+			buf = append(buf, ", "...)
+		}
+		buf = appendHstoreQuoted(buf, k)
+		buf = append(buf, "=>"...)
+		buf = appendHstoreQuoted(buf, m[k])
+	}
+	s := string(buf)
+	*b = buf
+	defaultBufferPool.Put(b)
+	return s
+}
+
+func appendHstoreQuoted(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf = append(buf, '\\')
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '"')
+}
@@ -0,0 +1,93 @@
+// hstore_test.go
+package octypes
+
+import "testing"
+
+func TestParseHstore(t *testing.T) {
+	pairs, err := ParseHstore(`"en"=>"Hello", "fr"=>NULL, "q"=>"say \"hi\"", bare=>value, "n"=>"NULL"`)
+	if err != nil {
+		t.Fatalf("Error parsing hstore: %v", err)
+	}
+	want := map[string]NullString{
+		"en":   *NewNullString("Hello"),
+		"fr":   {},
+		"q":    *NewNullString(`say "hi"`),
+		"bare": *NewNullString("value"),
+		"n":    *NewNullString("NULL"),
+	}
+	if len(pairs) != len(want) {
+		t.Errorf("Expected %d pairs, got %d", len(want), len(pairs))
+	}
+	for k, v := range want {
+		if pairs[k] != v {
+			t.Errorf("Key %s: expected %+v, got %+v", k, v, pairs[k])
+		}
+	}
+
+	pairs, err = ParseHstore("")
+	if err != nil || len(pairs) != 0 {
+		t.Errorf("Expected empty hstore and no error, got %v and %v", pairs, err)
+	}
+}
+
+func TestParseHstoreInvalid(t *testing.T) {
+	for _, in := range []string{`"a"`, `"a"=>`, `"a"=>"b" "c"=>"d"`, `"a=>"b"`, `NULL=>"x"`} {
+		if _, err := ParseHstore(in); err == nil {
+			t.Errorf("Expected error when parsing %q, got nil", in)
+		}
+	}
+}
+
+func TestFormatHstore(t *testing.T) {
+	got := FormatHstore(LocalizedText{"fr": "Bonjour", "en": `a "b" \c`})
+	want := `"en"=>"a \"b\" \\c", "fr"=>"Bonjour"`
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestLocalizedTextScanHstore(t *testing.T) {
+	lt := &LocalizedText{}
+	if err := lt.Scan([]byte(`"en"=>"Hello", "fr"=>"Bonjour", "de"=>NULL`)); err != nil {
+		t.Errorf("Error scanning hstore into LocalizedText: %v", err)
+	}
+	if len(*lt) != 2 || (*lt)["en"] != "Hello" || (*lt)["fr"] != "Bonjour" {
+		t.Errorf("Unexpected LocalizedText %v", *lt)
+	}
+
+	// JSON still works
+	if err := lt.Scan(`{"es":"Hola"}`); err != nil {
+		t.Errorf("Error scanning JSON into LocalizedText: %v", err)
+	}
+	if len(*lt) != 1 || (*lt)["es"] != "Hola" {
+		t.Errorf("Unexpected LocalizedText %v", *lt)
+	}
+
+	// Empty input is invalid JSON, not an empty hstore.
+	for _, src := range []interface{}{"", []byte("  ")} {
+		if err := lt.Scan(src); err == nil {
+			t.Errorf("Expected error scanning %q, got %v", src, *lt)
+		}
+	}
+}
+
+func TestLocalizedTextHstore(t *testing.T) {
+	var lh LocalizedTextHstore
+	if err := lh.Scan(`{"en":"Hi"}`); err != nil {
+		t.Errorf("Error scanning JSON into LocalizedTextHstore: %v", err)
+	}
+	val, err := lh.Value()
+	if err != nil {
+		t.Errorf("Error getting Value from LocalizedTextHstore: %v", err)
+	}
+	if val != `"en"=>"Hi"` {
+		t.Errorf("Expected Value '\"en\"=>\"Hi\"', got '%v'", val)
+	}
+
+	if err := lh.Scan(nil); err != nil || lh != nil {
+		t.Errorf("Expected nil LocalizedTextHstore and no error, got %v and %v", lh, err)
+	}
+	if val, _ := lh.Value(); val != nil {
+		t.Errorf("Expected nil Value, got %v", val)
+	}
+}
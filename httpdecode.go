@@ -0,0 +1,229 @@
+// httpdecode.go
+package octypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DecodeOptions configures DecodeJSONRequest. Zero fields use the defaults
+// documented on each field.
+type DecodeOptions struct {
+	// MaxBytes limits the request body size. It defaults to 1MB.
+	MaxBytes int64
+	// DisallowUnknownFields rejects objects with fields dst does not have.
+	DisallowUnknownFields bool
+	// Validate runs Validate on dst after decoding it.
+	Validate bool
+}
+
+// Problem is an RFC 9457 problem details payload.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Errors lists the offending fields, if known.
+	Errors []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField is an error attached to a field of the request body.
+type ProblemField struct {
+	// Field is the dotted path of the field, e.g. "address.city" for JSON
+	// errors or "Address.City" for validation errors.
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+// Write sends p as an application/problem+json response.
+func (p *Problem) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// newProblem returns a Problem with the default type.
+func newProblem(status int, detail string, fields ...ProblemField) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Errors: fields}
+}
+
+// DecodeJSONRequest decodes the JSON body of r into dst, typically a struct
+// of octypes fields. It returns nil on success, and otherwise a *Problem
+// ready to be written: 415 for a body that is not JSON, 413 for a body
+// larger than MaxBytes, and 400 for malformed JSON, type mismatches,
+// unknown fields and failed validation, with field paths when known.
+//
+//	var p *octypes.Problem
+//	if err := octypes.DecodeJSONRequest(r, &req, opts); errors.As(err, &p) {
+//		p.Write(w)
+//	}
+func DecodeJSONRequest(r *http.Request, dst interface{}, opts DecodeOptions) error {
+	// A nil *Problem must not be returned as a non-nil error.
+	if p := decodeJSONRequest(r, dst, opts); p != nil {
+		return p
+	}
+	return nil
+}
+
+func decodeJSONRequest(r *http.Request, dst interface{}, opts DecodeOptions) *Problem {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, _ := mime.ParseMediaType(ct)
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			return newProblem(http.StatusUnsupportedMediaType, fmt.Sprintf("content type %q is not JSON", mediaType))
+		}
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 1 << 20
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, opts.MaxBytes+1))
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "reading body: "+err.Error())
+	}
+	if int64(len(body)) > opts.MaxBytes {
+		return newProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("body exceeds %d bytes", opts.MaxBytes))
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		return decodeProblem(err, body, reflect.TypeOf(dst))
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return newProblem(http.StatusBadRequest, "body must contain a single JSON value")
+	}
+	if opts.Validate {
+		var errs ValidationErrors
+		if err := Validate(dst); errors.As(err, &errs) {
+			fields := make([]ProblemField, len(errs))
+			for i, fe := range errs {
+				fields[i] = ProblemField{Field: fe.Field, Detail: fe.Err.Error() + " (" + fe.Rule + ")"}
+			}
+			return newProblem(http.StatusBadRequest, "validation failed", fields...)
+		} else if err != nil {
+			return newProblem(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return nil
+}
+
+// decodeProblem describes the error json.Decoder returned for body decoded
+// into a value of type t.
+func decodeProblem(err error, body []byte, t reflect.Type) *Problem {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return newProblem(http.StatusBadRequest, "body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return newProblem(http.StatusBadRequest, "body is truncated JSON")
+	case errors.As(err, &syntaxErr):
+		return newProblem(http.StatusBadRequest, fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return newProblem(http.StatusBadRequest, "invalid field", ProblemField{Field: typeErr.Field, Detail: typeErrorDetail(typeErr)})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return newProblem(http.StatusBadRequest, "unknown field", ProblemField{Field: field, Detail: "is not allowed"})
+	}
+	// Errors returned by UnmarshalJSON methods, including those of the
+	// octypes types, carry no field path: find the field by decoding the
+	// body field by field.
+	if path, fieldErr := jsonErrorPath(body, t); path != "" {
+		detail := fieldErr.Error()
+		if errors.As(fieldErr, &typeErr) {
+			detail = typeErrorDetail(typeErr)
+		}
+		return newProblem(http.StatusBadRequest, "invalid field", ProblemField{Field: path, Detail: detail})
+	}
+	return newProblem(http.StatusBadRequest, err.Error())
+}
+
+func typeErrorDetail(err *json.UnmarshalTypeError) string {
+	return fmt.Sprintf("expected %s, got JSON %s", err.Type, err.Value)
+}
+
+// jsonErrorPath returns the dotted JSON path of the first field of struct
+// type t that fails to decode from data, along with its error. Fields of
+// nested structs are searched recursively; elements of arrays and maps are
+// not. It returns an empty path if no field fails on its own.
+func jsonErrorPath(data []byte, t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return "", nil
+	}
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(data, &obj) != nil {
+		return "", nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		if f.Anonymous && !hasTag {
+			if path, err := jsonErrorPath(data, f.Type); path != "" {
+				return path, err
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		raw, ok := obj[name]
+		if !ok {
+			for k, v := range obj {
+				if strings.EqualFold(k, name) {
+					raw, ok = v, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, reflect.New(f.Type).Interface()); err != nil {
+			if path, fieldErr := jsonErrorPath(raw, f.Type); path != "" {
+				return name + "." + path, fieldErr
+			}
+			return name, err
+		}
+	}
+	return "", nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// DecodeJSON is DecodeJSONRequest for handlers: on failure it writes the
+// problem to w and returns false.
+//
+//	var req CreateProduct
+//	if !octypes.DecodeJSON(w, r, &req, octypes.DecodeOptions{Validate: true}) {
+//		return
+//	}
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}, opts DecodeOptions) bool {
+	if p := decodeJSONRequest(r, dst, opts); p != nil {
+		p.Write(w)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// httpdecode_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type decodeAddress struct {
+	City NullString `json:"city" validate:"required"`
+}
+
+type decodeProduct struct {
+	Name    NullString    `json:"name" validate:"required,maxlen=10"`
+	Price   NullFloat64   `json:"price"`
+	Title   LocalizedText `json:"title"`
+	Address decodeAddress `json:"address"`
+}
+
+func decodeRequest(body, contentType string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestDecodeJSONRequest(t *testing.T) {
+	var p decodeProduct
+	r := decodeRequest(`{"name":"Chair","price":9.5,"title":{"en":"Chair"},"address":{"city":"Paris"}}`, "application/json")
+	// The result must be a nil error, not a nil *Problem.
+	if err := DecodeJSONRequest(r, &p, DecodeOptions{Validate: true}); err != nil {
+		t.Fatalf("Unexpected error %+v", err)
+	}
+	if p.Name.String != "Chair" || p.Price.Float64 != 9.5 || p.Address.City.String != "Paris" {
+		t.Errorf("Unexpected product %#v", p)
+	}
+
+	tests := []struct {
+		name, body, contentType string
+		opts                    DecodeOptions
+		status                  int
+		field                   string
+	}{
+		{"not JSON", `name=x`, "application/x-www-form-urlencoded", DecodeOptions{}, 415, ""},
+		{"empty", ``, "application/json", DecodeOptions{}, 400, ""},
+		{"malformed", `{"name":}`, "application/json", DecodeOptions{}, 400, ""},
+		{"truncated", `{"name":"x"`, "", DecodeOptions{}, 400, ""},
+		{"type mismatch", `{"address":{"city":5}}`, "", DecodeOptions{}, 400, "address.city"},
+		{"custom unmarshaler", `{"title":5}`, "", DecodeOptions{}, 400, "title"},
+		{"unknown field", `{"colour":"red"}`, "", DecodeOptions{DisallowUnknownFields: true}, 400, "colour"},
+		{"trailing data", `{} {}`, "", DecodeOptions{}, 400, ""},
+		{"too large", `{"name":"` + strings.Repeat("x", 100) + `"}`, "", DecodeOptions{MaxBytes: 20}, 413, ""},
+		{"validation", `{"name":"A very long name"}`, "", DecodeOptions{Validate: true}, 400, "Name"},
+	}
+	for _, tt := range tests {
+		var p decodeProduct
+		var prob *Problem
+		if err := DecodeJSONRequest(decodeRequest(tt.body, tt.contentType), &p, tt.opts); !errors.As(err, &prob) {
+			t.Errorf("%s: Expected a problem, got %v", tt.name, err)
+			continue
+		}
+		if prob.Status != tt.status {
+			t.Errorf("%s: Expected status %d, got %+v", tt.name, tt.status, prob)
+		}
+		if tt.field != "" && (len(prob.Errors) == 0 || prob.Errors[0].Field != tt.field) {
+			t.Errorf("%s: Expected error on field %s, got %+v", tt.name, tt.field, prob.Errors)
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	var p decodeProduct
+	if DecodeJSON(w, decodeRequest(`{"price":"cheap"}`, ""), &p, DecodeOptions{}) {
+		t.Fatalf("Expected decoding to fail")
+	}
+	if w.Code != 400 || w.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("Expected 400 problem+json, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	var prob Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &prob); err != nil || prob.Status != 400 || prob.Title != "Bad Request" {
+		t.Errorf("Unexpected problem %s", w.Body.String())
+	}
+}
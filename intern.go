@@ -0,0 +1,375 @@
+// intern.go
+package octypes
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+	"unique"
+)
+
+// InternPool deduplicates strings so that equal strings decoded from many
+// rows share one allocation. It keeps at most capacity strings, evicting the
+// least recently used. It is safe for concurrent use.
+type InternPool struct {
+	mu        sync.Mutex
+	capacity  int
+	minSize   int
+	maxSize   int
+	window    internWindow
+	ghosts    map[string]*list.Element
+	ghostLRU  list.List
+	minLength int
+	maxAge    time.Duration
+	weak      bool
+	lastSweep time.Time
+	entries   map[string]*list.Element
+	lru       list.List
+	stats     InternStats
+}
+
+// internEntry is the value of the pool's list elements.
+type internEntry struct {
+	s     string
+	added time.Time
+}
+
+// internTuneWindow is the number of lookups between two capacity
+// adjustments of an adaptive InternPool.
+const internTuneWindow = 1000
+
+// internWindow counts the lookups of the current tuning window. Ghost hits
+// are misses on recently evicted strings, which a larger pool would have
+// hit.
+type internWindow struct {
+	hits, misses, ghostHits uint64
+}
+
+// InternStats are the counters of an InternPool.
+type InternStats struct {
+	// Hits counts lookups that returned a pooled string.
+	Hits uint64 `json:"hits"`
+	// Misses counts lookups that added a string.
+	Misses uint64 `json:"misses"`
+	// Evictions counts strings dropped to respect the pool size.
+	Evictions uint64 `json:"evictions"`
+	// Expirations counts strings dropped because they exceeded MaxAge.
+	Expirations uint64 `json:"expirations"`
+	// Size is the current number of pooled strings.
+	Size int `json:"size"`
+	// Capacity is the current maximum number of pooled strings, which
+	// changes over time for adaptive pools.
+	Capacity int `json:"capacity"`
+	// BytesSaved estimates the string bytes not retained thanks to hits.
+	BytesSaved uint64 `json:"bytes_saved"`
+}
+
+// InternOptions configures an InternPool.
+type InternOptions struct {
+	// Size is the maximum number of pooled strings. Zero or less means
+	// unbounded. For adaptive pools it is the initial capacity.
+	Size int
+	// MaxSize, if positive, makes the pool adaptive. It remembers as many
+	// recently evicted strings as it holds, and every 1000 lookups doubles
+	// its capacity, up to MaxSize, when a quarter of the lookups missed on
+	// one of them, and halves it, down to MinSize, when fewer than a tenth
+	// of the lookups hit or would have hit, so mostly unique values do not
+	// fill the pool. A Size of zero starts at MaxSize.
+	MaxSize int
+	// MinSize is the lower capacity bound of adaptive pools. It defaults
+	// to 1.
+	MinSize int
+	// MinLength is the length in bytes below which strings are returned
+	// without being pooled.
+	MinLength int
+	// MaxAge, if positive, drops strings pooled longer ago than MaxAge,
+	// however often they are used. Expired strings are dropped when looked
+	// up and by a sweep of the whole pool run at most once per MaxAge
+	// while strings are added, or explicitly with Sweep.
+	MaxAge time.Duration
+	// Weak interns through the unique package instead of a bounded table:
+	// strings are kept only while something else references them, so the
+	// pool needs no sizing. Size and MaxAge are ignored, and Stats only
+	// counts lookups, as Misses.
+	Weak bool
+}
+
+// NewInternPool returns an InternPool holding at most capacity strings. A
+// capacity of zero or less means unbounded.
+func NewInternPool(capacity int) *InternPool {
+	return NewInternPoolWithOptions(InternOptions{Size: capacity})
+}
+
+// NewInternPoolWithOptions returns an InternPool configured by opts.
+func NewInternPoolWithOptions(opts InternOptions) *InternPool {
+	if opts.MaxSize > 0 {
+		opts.MinSize = max(opts.MinSize, 1)
+		if opts.Size <= 0 {
+			opts.Size = opts.MaxSize
+		}
+		opts.Size = min(max(opts.Size, opts.MinSize), opts.MaxSize)
+	}
+	return &InternPool{
+		capacity:  opts.Size,
+		minSize:   opts.MinSize,
+		maxSize:   opts.MaxSize,
+		minLength: opts.MinLength,
+		maxAge:    opts.MaxAge,
+		weak:      opts.Weak,
+		lastSweep: nowFunc(),
+		entries:   make(map[string]*list.Element),
+	}
+}
+
+// Intern returns the pooled string equal to s, adding s if absent.
+func (p *InternPool) Intern(s string) string {
+	if len(s) < p.minLength {
+		return s
+	}
+	if p.weak {
+		return p.internWeak(s)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.entries[s]; ok && !p.expire(e) {
+		return p.hit(e)
+	}
+	p.add(s)
+	return s
+}
+
+// InternBytes returns the pooled string equal to b. Unlike Intern(string(b))
+// it does not allocate when b is already pooled.
+func (p *InternPool) InternBytes(b []byte) string {
+	if len(b) < p.minLength {
+		return string(b)
+	}
+	if p.weak {
+		return p.internWeak(string(b))
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.entries[string(b)]; ok && !p.expire(e) {
+		return p.hit(e)
+	}
+	s := string(b)
+	p.add(s)
+	return s
+}
+
+// Preload adds strings to the pool without counting them as lookups, so a
+// service can warm it with known-hot values such as locale codes at
+// startup. Later strings are treated as more recently used; if there are
+// more strings than the pool holds, the last ones are kept. Preload does
+// nothing for weak pools, which only keep referenced strings.
+func (p *InternPool) Preload(strs []string) {
+	if p.weak {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := nowFunc()
+	for _, s := range strs {
+		if len(s) < p.minLength {
+			continue
+		}
+		if e, ok := p.entries[s]; ok {
+			p.lru.MoveToFront(e)
+			continue
+		}
+		if p.capacity > 0 && len(p.entries) >= p.capacity {
+			p.evict()
+		}
+		p.entries[s] = p.lru.PushFront(&internEntry{s: s, added: now})
+	}
+}
+
+// Snapshot returns the pooled strings from least to most recently used,
+// for example to persist them at shutdown. Passing the result to Restore,
+// possibly on another pool, reproduces the pool's contents and order.
+func (p *InternPool) Snapshot() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	strs := make([]string, 0, len(p.entries))
+	for e := p.lru.Back(); e != nil; e = e.Prev() {
+		strs = append(strs, e.Value.(*internEntry).s)
+	}
+	return strs
+}
+
+// Restore adds the strings of a Snapshot to the pool. It is Preload under
+// a name that pairs with Snapshot.
+func (p *InternPool) Restore(snapshot []string) {
+	p.Preload(snapshot)
+}
+
+// internWeak returns the canonical copy of s held by the unique package.
+func (p *InternPool) internWeak(s string) string {
+	p.mu.Lock()
+	p.stats.Misses++
+	p.mu.Unlock()
+	return unique.Make(s).Value()
+}
+
+// hit marks e as most recently used and returns its string. p.mu must be
+// held.
+func (p *InternPool) hit(e *list.Element) string {
+	s := e.Value.(*internEntry).s
+	p.lru.MoveToFront(e)
+	p.stats.Hits++
+	p.stats.BytesSaved += uint64(len(s))
+	p.window.hits++
+	p.tune()
+	return s
+}
+
+// add inserts s, evicting the least recently used string if the pool is
+// full. p.mu must be held.
+func (p *InternPool) add(s string) {
+	p.stats.Misses++
+	now := nowFunc()
+	if p.maxAge > 0 && now.Sub(p.lastSweep) >= p.maxAge {
+		p.sweep(now)
+	}
+	if g, ok := p.ghosts[s]; ok {
+		p.window.ghostHits++
+		p.ghostLRU.Remove(g)
+		delete(p.ghosts, s)
+	}
+	if p.capacity > 0 && len(p.entries) >= p.capacity {
+		p.evict()
+	}
+	p.entries[s] = p.lru.PushFront(&internEntry{s: s, added: now})
+	p.window.misses++
+	p.tune()
+}
+
+// evict drops the least recently used string, remembering it as a ghost
+// in adaptive pools. p.mu must be held.
+func (p *InternPool) evict() {
+	e := p.lru.Back()
+	p.remove(e)
+	p.stats.Evictions++
+	if p.maxSize <= 0 {
+		return
+	}
+	if p.ghosts == nil {
+		p.ghosts = make(map[string]*list.Element)
+	}
+	s := e.Value.(*internEntry).s
+	p.ghosts[s] = p.ghostLRU.PushFront(s)
+	for len(p.ghosts) > p.capacity {
+		g := p.ghostLRU.Back()
+		p.ghostLRU.Remove(g)
+		delete(p.ghosts, g.Value.(string))
+	}
+}
+
+// tune adjusts the capacity of an adaptive pool at the end of each tuning
+// window. p.mu must be held.
+func (p *InternPool) tune() {
+	w := p.window
+	n := w.hits + w.misses
+	if p.maxSize <= 0 || n < internTuneWindow {
+		return
+	}
+	p.window = internWindow{}
+	switch {
+	case w.ghostHits*4 >= n:
+		p.capacity = min(p.capacity*2, p.maxSize)
+	case (w.hits+w.ghostHits)*10 < n:
+		p.capacity = max(p.capacity/2, p.minSize)
+		for len(p.entries) > p.capacity {
+			p.evict()
+		}
+	}
+}
+
+// remove drops e from the pool. p.mu must be held.
+func (p *InternPool) remove(e *list.Element) {
+	p.lru.Remove(e)
+	delete(p.entries, e.Value.(*internEntry).s)
+}
+
+// expire removes e and reports true if it exceeded the pool's MaxAge.
+// p.mu must be held.
+func (p *InternPool) expire(e *list.Element) bool {
+	if p.maxAge <= 0 || nowFunc().Sub(e.Value.(*internEntry).added) < p.maxAge {
+		return false
+	}
+	p.remove(e)
+	p.stats.Expirations++
+	return true
+}
+
+// Sweep drops every string older than the pool's MaxAge.
+func (p *InternPool) Sweep() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sweep(nowFunc())
+}
+
+// sweep drops every string added before now minus MaxAge. p.mu must be held.
+func (p *InternPool) sweep(now time.Time) {
+	p.lastSweep = now
+	if p.maxAge <= 0 {
+		return
+	}
+	for e := p.lru.Front(); e != nil; {
+		next := e.Next()
+		if now.Sub(e.Value.(*internEntry).added) >= p.maxAge {
+			p.remove(e)
+			p.stats.Expirations++
+		}
+		e = next
+	}
+}
+
+// Stats returns a snapshot of the pool's counters.
+func (p *InternPool) Stats() InternStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.stats
+	st.Size = len(p.entries)
+	st.Capacity = p.capacity
+	return st
+}
+
+// String returns Stats as JSON, so that the pool implements expvar.Var
+// and can be published with expvar.Publish.
+func (p *InternPool) String() string {
+	b, _ := json.Marshal(p.Stats())
+	return string(b)
+}
+
+// Len returns the number of pooled strings.
+func (p *InternPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.entries)
+}
+
+// DefaultInternPool returns the package-level InternPool used by
+// LocalizedText and IntDictionary decoding once SetInterning is enabled.
+func DefaultInternPool() *InternPool {
+	return defaultInternPool
+}
+
+// mapInternPool returns the pool used when decoding map types, or nil if
+// interning is off.
+func mapInternPool() *InternPool {
+	if internEnabled {
+		return defaultInternPool
+	}
+	return nil
+}
+
+// internMap returns m with keys and values interned in pool.
+func internMap(pool *InternPool, m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[pool.Intern(k)] = pool.Intern(v)
+	}
+	return out
+}
@@ -0,0 +1,232 @@
+// intern_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestInternPool(t *testing.T) {
+	p := NewInternPool(2)
+	a := p.Intern(string([]byte("en")))
+	b := p.InternBytes([]byte("en"))
+	if unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Errorf("Expected interned strings to share memory")
+	}
+
+	p.Intern("fr")
+	p.Intern("en") // en is now most recently used
+	p.Intern("de") // evicts fr
+	if p.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", p.Len())
+	}
+	if c := p.Intern(string([]byte("en"))); unsafe.StringData(c) != unsafe.StringData(a) {
+		t.Errorf("Expected en to survive eviction")
+	}
+
+	unbounded := NewInternPool(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				unbounded.Intern(string(rune('a' + j%26)))
+			}
+		}()
+	}
+	wg.Wait()
+	if unbounded.Len() != 26 {
+		t.Errorf("Expected 26 entries, got %d", unbounded.Len())
+	}
+}
+
+func TestLocalizedTextInterning(t *testing.T) {
+	pool := NewInternPool(0)
+	SetLocalizedTextInterning(pool)
+	defer SetLocalizedTextInterning(nil)
+
+	var a, b LocalizedText
+	if err := a.Scan([]byte(`{"en":"Yes"}`)); err != nil {
+		t.Fatalf("Error scanning: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"en":"Yes"}`), &b); err != nil {
+		t.Fatalf("Error unmarshalling: %v", err)
+	}
+	if unsafe.StringData(a["en"]) != unsafe.StringData(b["en"]) {
+		t.Errorf("Expected values to be interned")
+	}
+	if pool.Len() != 2 {
+		t.Errorf("Expected 2 pooled strings, got %d", pool.Len())
+	}
+}
+
+func TestDefaultInternPool(t *testing.T) {
+	defer ConfigureInternPool(defaultInternOptions)
+	ConfigureInternPool(InternOptions{Size: 100, MinLength: 2})
+	pool := DefaultInternPool()
+
+	var a, b IntDictionary
+	if err := a.Scan(`{"x":1,"views":2}`); err != nil {
+		t.Fatalf("Error scanning: %v", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Expected no interning while disabled, got %d entries", pool.Len())
+	}
+
+	SetInterning(true)
+	defer SetInterning(false)
+	if err := a.Scan(`{"x":1,"views":2}`); err != nil {
+		t.Fatalf("Error scanning: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"views":3}`), &b); err != nil {
+		t.Fatalf("Error unmarshalling: %v", err)
+	}
+	if b["views"] != 3 || len(b) != 1 {
+		t.Errorf("Expected {views:3}, got %v", b)
+	}
+	if pool.Len() != 1 {
+		t.Errorf("Expected only keys of at least 2 bytes pooled, got %d entries", pool.Len())
+	}
+	for k := range a {
+		for k2 := range b {
+			if k == k2 && unsafe.StringData(k) != unsafe.StringData(k2) {
+				t.Errorf("Expected key %q to be interned", k)
+			}
+		}
+	}
+
+	override := NewInternPool(0)
+	SetLocalizedTextInterning(override)
+	defer SetLocalizedTextInterning(nil)
+	var lt LocalizedText
+	if err := lt.Scan(`{"en":"Hello"}`); err != nil {
+		t.Fatalf("Error scanning: %v", err)
+	}
+	if override.Len() != 2 || pool.Len() != 1 {
+		t.Errorf("Expected the LocalizedText pool to take precedence, got %d and %d", override.Len(), pool.Len())
+	}
+}
+
+func TestInternPoolStats(t *testing.T) {
+	p := NewInternPoolWithOptions(InternOptions{Size: 2, MinLength: 2})
+	p.Intern("en")
+	p.InternBytes([]byte("en"))
+	p.Intern("fr")
+	p.Intern("de")
+	p.Intern("x")
+
+	want := InternStats{Hits: 1, Misses: 3, Evictions: 1, Size: 2, Capacity: 2, BytesSaved: 2}
+	if got := p.Stats(); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	var got InternStats
+	if err := json.Unmarshal([]byte(p.String()), &got); err != nil || got != want {
+		t.Errorf("Expected expvar %+v, got %+v and error %v", want, got, err)
+	}
+}
+
+func TestInternPoolMaxAge(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	SetNowFunc(func() time.Time { return now })
+	defer SetNowFunc(nil)
+
+	p := NewInternPoolWithOptions(InternOptions{MaxAge: time.Minute})
+	first := p.Intern(string([]byte("en")))
+	p.Intern("fr")
+
+	now = now.Add(30 * time.Second)
+	if got := p.Intern(string([]byte("en"))); unsafe.StringData(got) != unsafe.StringData(first) {
+		t.Errorf("Expected en to be pooled before MaxAge")
+	}
+
+	now = now.Add(31 * time.Second)
+	if got := p.Intern(string([]byte("en"))); unsafe.StringData(got) == unsafe.StringData(first) {
+		t.Errorf("Expected en to expire after MaxAge despite being used")
+	}
+	// Adding en ran a sweep, which dropped fr.
+	if st := p.Stats(); st.Size != 1 || st.Expirations != 2 {
+		t.Errorf("Expected 1 entry and 2 expirations, got %+v", st)
+	}
+
+	now = now.Add(time.Minute)
+	p.Sweep()
+	if p.Len() != 0 {
+		t.Errorf("Expected empty pool after Sweep, got %d entries", p.Len())
+	}
+}
+
+func TestInternPoolWeak(t *testing.T) {
+	p := NewInternPoolWithOptions(InternOptions{Weak: true, Size: 1})
+	a := p.Intern(string([]byte("en-US")))
+	b := p.InternBytes([]byte("en-US"))
+	c := p.Intern(string([]byte("fr-FR")))
+	if unsafe.StringData(a) != unsafe.StringData(b) || a != "en-US" || c != "fr-FR" {
+		t.Errorf("Expected weakly interned strings to share memory")
+	}
+	if d := p.Intern(string([]byte("en-US"))); unsafe.StringData(d) != unsafe.StringData(a) {
+		t.Errorf("Expected Size to be ignored in weak mode")
+	}
+	if st := p.Stats(); st.Misses != 4 || st.Size != 0 {
+		t.Errorf("Expected 4 lookups and no table, got %+v", st)
+	}
+}
+
+func TestInternPoolAdaptive(t *testing.T) {
+	p := NewInternPoolWithOptions(InternOptions{Size: 10, MinSize: 5, MaxSize: 40})
+
+	// A hot working set of 20 strings thrashes a pool of 10 until it grows.
+	for i := 0; i < 5*internTuneWindow; i++ {
+		p.Intern("hot" + strconv.Itoa(i%20))
+	}
+	if got := p.Stats().Capacity; got != 20 && got != 40 {
+		t.Errorf("Expected capacity to grow to fit the working set, got %d", got)
+	}
+
+	// Unique strings make the pool shrink to its lower bound.
+	for i := 0; i < 10*internTuneWindow; i++ {
+		p.Intern("unique" + strconv.Itoa(i))
+	}
+	if st := p.Stats(); st.Capacity != 5 || st.Size > 5 {
+		t.Errorf("Expected capacity and size of at most 5, got %d and %d", st.Capacity, st.Size)
+	}
+
+	if got := NewInternPoolWithOptions(InternOptions{MaxSize: 8}).Stats().Capacity; got != 8 {
+		t.Errorf("Expected initial capacity 8, got %d", got)
+	}
+}
+
+func TestInternPoolPreload(t *testing.T) {
+	p := NewInternPoolWithOptions(InternOptions{Size: 3, MinLength: 2})
+	p.Preload([]string{"en", "fr", "x", "de", "it"})
+
+	if st := p.Stats(); st.Size != 3 || st.Misses != 0 || st.Hits != 0 {
+		t.Errorf("Expected 3 preloaded strings and no lookups, got %+v", st)
+	}
+	snap := p.Snapshot()
+	if want := []string{"fr", "de", "it"}; !reflect.DeepEqual(snap, want) {
+		t.Errorf("Expected snapshot %v, got %v", want, snap)
+	}
+
+	q := NewInternPool(3)
+	q.Restore(snap)
+	if got := q.Snapshot(); !reflect.DeepEqual(got, snap) {
+		t.Errorf("Expected restored snapshot %v, got %v", snap, got)
+	}
+	q.Intern(string([]byte("fr")))
+	if st := q.Stats(); st.Hits != 1 {
+		t.Errorf("Expected a hit on a restored string, got %+v", st)
+	}
+
+	w := NewInternPoolWithOptions(InternOptions{Weak: true})
+	w.Preload([]string{"en"})
+	if got := w.Snapshot(); len(got) != 0 {
+		t.Errorf("Expected empty weak snapshot, got %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+// jsoncount.go
+package octypes
+
+// jsonObjectLen counts the members of the JSON object b by scanning its
+// top level, so decoding can allocate the target map at its final size.
+// It returns 0 if b is not an object; malformed input gives an arbitrary
+// count, which is only used as a size hint.
+func jsonObjectLen(b []byte) int {
+	i := 0
+	for i < len(b) && isJSONSpace(b[i]) {
+		i++
+	}
+	if i == len(b) || b[i] != '{' {
+		return 0
+	}
+	n, depth, empty := 0, 0, true
+	for ; i < len(b); i++ {
+		switch c := b[i]; c {
+		case '"':
+			// Skip the string, including escaped quotes.
+			for i++; i < len(b) && b[i] != '"'; i++ {
+				if b[i] == '\\' {
+					i++
+				}
+			}
+			empty = false
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				if empty {
+					return 0
+				}
+				return n + 1
+			}
+		case ',':
+			if depth == 1 {
+				n++
+			}
+		default:
+			if !isJSONSpace(c) {
+				empty = false
+			}
+		}
+	}
+	return n + 1
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
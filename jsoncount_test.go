@@ -0,0 +1,55 @@
+// jsoncount_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestJSONObjectLen(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{`null`, 0},
+		{`[1,2]`, 0},
+		{`{}`, 0},
+		{` { } `, 0},
+		{`{"a":1}`, 1},
+		{`{"a":"x,y","b":"}"}`, 2},
+		{`{"a\",":1,"b":[1,2,3],"c":{"d":1,"e":2}}`, 3},
+		{` {"en" : "Hello" , "fr" : "Bonjour"} `, 2},
+	}
+	for _, tt := range tests {
+		if got := jsonObjectLen([]byte(tt.in)); got != tt.want {
+			t.Errorf("Expected %d members in %s, got %d", tt.want, tt.in, got)
+		}
+	}
+}
+
+func TestDecodeJSONNullStaysNil(t *testing.T) {
+	lt := LocalizedText{"en": "x"}
+	id := IntDictionary{"a": 1}
+	if err := json.Unmarshal([]byte("null"), &lt); err != nil || lt != nil {
+		t.Errorf("Expected nil LocalizedText, got %v, %v", lt, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &id); err != nil || id != nil {
+		t.Errorf("Expected nil IntDictionary, got %v, %v", id, err)
+	}
+}
+
+func BenchmarkIntDictionaryUnmarshalJSON(b *testing.B) {
+	m := make(map[string]int, 1000)
+	for i := 0; i < 1000; i++ {
+		m["key"+strconv.Itoa(i)] = i
+	}
+	data, _ := json.Marshal(m)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var id IntDictionary
+		if err := id.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
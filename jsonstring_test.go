@@ -0,0 +1,60 @@
+// jsonstring_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAppendJSONString(t *testing.T) {
+	tests := []string{
+		"",
+		"hello",
+		`say "hi" \ bye`,
+		"<script>&</script>",
+		"tab\tnew\nline\rfeed\fback\b",
+		"\x00\x01\x1f\x7f",
+		"café ünïcödé 日本語 🎉",
+		"line\u2028para\u2029end",
+		"bad \xff utf8 \xc3",
+		strings.Repeat("long description ", 100),
+	}
+	for _, s := range tests {
+		got := appendJSONString([]byte("x"), s)
+		want, _ := json.Marshal(s)
+		if string(got) != "x"+string(want) {
+			t.Errorf("Expected x%s, got %s", want, got)
+		}
+		if got, _ := NewNullString(s).MarshalJSON(); s != "" && string(got) != string(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func FuzzAppendJSONString(f *testing.F) {
+	f.Add("hello <world>")
+	f.Add("\xff \x00")
+	f.Fuzz(func(t *testing.T, s string) {
+		want, _ := json.Marshal(s)
+		if got := appendJSONString(nil, s); string(got) != string(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+}
+
+func BenchmarkNullStringMarshalJSON(b *testing.B) {
+	ns := *NewNullString(strings.Repeat("A long product description, with \"quotes\" & more. ", 20))
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ns.MarshalJSON()
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			json.Marshal(ns.String)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+// jsonvalue.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// JSONValueMode selects the driver.Value representation of JSON-backed
+// types such as LocalizedText and IntDictionary.
+type JSONValueMode uint8
+
+const (
+	// JSONValueBytes returns the JSON document as []byte. This is the default.
+	JSONValueBytes JSONValueMode = iota
+	// JSONValueString returns the JSON document as a string, for drivers or
+	// parameter modes that only accept jsonb parameters as text.
+	JSONValueString
+	// JSONValueNative returns the plain Go map, letting drivers that accept
+	// arbitrary values (such as pgx through its NamedValueChecker) encode it
+	// with their own JSON codec.
+	JSONValueNative
+)
+
+// jsonValue returns the driver.Value of v according to the configured
+// JSONValueMode. native is the value returned in JSONValueNative mode; it
+// must not implement driver.Valuer itself.
+func jsonValue(v interface{}, native interface{}) (driver.Value, error) {
+	switch jsonValueMode {
+	case JSONValueNative:
+		return native, nil
+	case JSONValueString:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return json.Marshal(v)
+}
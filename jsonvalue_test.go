@@ -0,0 +1,45 @@
+// jsonvalue_test.go
+package octypes
+
+import "testing"
+
+func TestSetJSONValueMode(t *testing.T) {
+	defer SetJSONValueMode(JSONValueBytes)
+	lt := LocalizedText{"en": "Hi"}
+	id := IntDictionary{"one": 1}
+
+	SetJSONValueMode(JSONValueString)
+	val, err := lt.Value()
+	if err != nil {
+		t.Errorf("Error getting Value from LocalizedText: %v", err)
+	}
+	if val != `{"en":"Hi"}` {
+		t.Errorf("Expected string Value '{\"en\":\"Hi\"}', got %#v", val)
+	}
+	val, _ = id.Value()
+	if val != `{"one":1}` {
+		t.Errorf("Expected string Value '{\"one\":1}', got %#v", val)
+	}
+
+	SetJSONValueMode(JSONValueNative)
+	val, _ = lt.Value()
+	if m, ok := val.(map[string]string); !ok || m["en"] != "Hi" {
+		t.Errorf("Expected map[string]string Value, got %#v", val)
+	}
+	val, _ = id.Value()
+	if m, ok := val.(map[string]int); !ok || m["one"] != 1 {
+		t.Errorf("Expected map[string]int Value, got %#v", val)
+	}
+
+	// Null stays nil in every mode
+	var nullText LocalizedText
+	if val, _ := nullText.Value(); val != nil {
+		t.Errorf("Expected nil Value, got %#v", val)
+	}
+
+	SetJSONValueMode(JSONValueBytes)
+	val, _ = lt.Value()
+	if b, ok := val.([]byte); !ok || string(b) != `{"en":"Hi"}` {
+		t.Errorf("Expected []byte Value, got %#v", val)
+	}
+}
@@ -0,0 +1,187 @@
+// keyset.go
+package octypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidKeyset is returned by DecodeKeyset for malformed tokens.
+var ErrInvalidKeyset = errors.New("invalid keyset token")
+
+// keysetValue is one encoded value of a keyset token: a type letter and
+// the value as text, so values scan back with their original Go type.
+type keysetValue [2]string
+
+// EncodeKeyset returns an opaque, URL-safe "after" token holding the sort
+// key of the last row of a page, e.g. EncodeKeyset(row.CreatedAt, row.ID).
+// Null values are preserved.
+func EncodeKeyset(values ...driver.Valuer) (string, error) {
+	encoded := make([]keysetValue, len(values))
+	for i, valuer := range values {
+		v, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		switch v := v.(type) {
+		case nil:
+			encoded[i] = keysetValue{"n", ""}
+		case int64:
+			encoded[i] = keysetValue{"i", strconv.FormatInt(v, 10)}
+		case float64:
+			encoded[i] = keysetValue{"f", strconv.FormatFloat(v, 'g', -1, 64)}
+		case bool:
+			encoded[i] = keysetValue{"b", strconv.FormatBool(v)}
+		case string:
+			encoded[i] = keysetValue{"s", v}
+		case []byte:
+			encoded[i] = keysetValue{"x", base64.StdEncoding.EncodeToString(v)}
+		case time.Time:
+			encoded[i] = keysetValue{"t", v.Format(time.RFC3339Nano)}
+		default:
+			return "", fmt.Errorf("cannot encode %T in a keyset token", v)
+		}
+	}
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeKeyset scans the values of a token made by EncodeKeyset into dst,
+// which must match the encoded values in number and order.
+func DecodeKeyset(token string, dst ...sql.Scanner) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidKeyset
+	}
+	var encoded []keysetValue
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		return ErrInvalidKeyset
+	}
+	if len(encoded) != len(dst) {
+		return fmt.Errorf("%w: %d values for %d destinations", ErrInvalidKeyset, len(encoded), len(dst))
+	}
+	for i, e := range encoded {
+		v, err := e.decode()
+		if err != nil {
+			return err
+		}
+		if err := dst[i].Scan(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e keysetValue) decode() (interface{}, error) {
+	var v interface{}
+	var err error
+	switch e[0] {
+	case "n":
+		return nil, nil
+	case "i":
+		v, err = strconv.ParseInt(e[1], 10, 64)
+	case "f":
+		v, err = strconv.ParseFloat(e[1], 64)
+	case "b":
+		v, err = strconv.ParseBool(e[1])
+	case "s":
+		v = e[1]
+	case "x":
+		v, err = base64.StdEncoding.DecodeString(e[1])
+	case "t":
+		v, err = time.Parse(time.RFC3339Nano, e[1])
+	default:
+		err = errors.New("unknown type")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyset, err)
+	}
+	return v, nil
+}
+
+// KeysetColumn is a sort column of a keyset-paginated query.
+type KeysetColumn struct {
+	Name string
+	Desc bool
+}
+
+// KeysetOrderBy returns the ORDER BY list matching KeysetWhere, e.g.
+// "created_at DESC NULLS LAST, id ASC NULLS LAST". Nulls sort last in both
+// directions, so paging over nullable columns is stable.
+func KeysetOrderBy(cols []KeysetColumn) string {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		dir := " ASC"
+		if c.Desc {
+			dir = " DESC"
+		}
+		parts[i] = c.Name + dir + " NULLS LAST"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// KeysetWhere returns a condition selecting the rows after the row whose
+// sort key is after, in the order of KeysetOrderBy(cols), with bind
+// parameters formatted by placeholder starting at n, and the matching
+// arguments. A nil placeholder uses PlaceholderDollar. Column names are
+// written as is and must come from trusted code.
+func KeysetWhere(cols []KeysetColumn, after []driver.Valuer, placeholder Placeholder, n int) (string, []interface{}, error) {
+	if len(cols) != len(after) {
+		return "", nil, fmt.Errorf("%d keyset values for %d columns", len(after), len(cols))
+	}
+	if placeholder == nil {
+		placeholder = PlaceholderDollar
+	}
+	values := make([]driver.Value, len(after))
+	for i, valuer := range after {
+		v, err := valuer.Value()
+		if err != nil {
+			return "", nil, err
+		}
+		values[i] = v
+	}
+
+	var args []interface{}
+	param := func(v driver.Value) string {
+		args = append(args, v)
+		return placeholder(n + len(args) - 1)
+	}
+	var disjuncts []string
+	for i, c := range cols {
+		// Nothing sorts after a null, since nulls come last.
+		if values[i] == nil {
+			continue
+		}
+		var terms []string
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				terms = append(terms, cols[j].Name+" IS NULL")
+			} else {
+				terms = append(terms, cols[j].Name+" = "+param(values[j]))
+			}
+		}
+		op := " > "
+		if c.Desc {
+			op = " < "
+		}
+		terms = append(terms, "("+c.Name+op+param(values[i])+" OR "+c.Name+" IS NULL)")
+		disjuncts = append(disjuncts, strings.Join(terms, " AND "))
+	}
+	if len(disjuncts) == 0 {
+		return "1 = 0", nil, nil
+	}
+	if len(disjuncts) == 1 {
+		return disjuncts[0], args, nil
+	}
+	return "(" + strings.Join(disjuncts, ") OR (") + ")", args, nil
+}
@@ -0,0 +1,70 @@
+// keyset_test.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeysetToken(t *testing.T) {
+	created := NewCustomTime(time.Date(2023, 6, 15, 10, 20, 30, 5, time.UTC))
+	id := NewNullInt64(42)
+	name := NullString{}
+	score := NewNullFloat64(1.5)
+
+	token, err := EncodeKeyset(created, id, name, score)
+	if err != nil {
+		t.Fatalf("Error encoding keyset: %v", err)
+	}
+
+	var gotCreated CustomTime
+	var gotID NullInt64
+	gotName := *NewNullString("stale")
+	var gotScore NullFloat64
+	if err := DecodeKeyset(token, &gotCreated, &gotID, &gotName, &gotScore); err != nil {
+		t.Fatalf("Error decoding keyset: %v", err)
+	}
+	if !gotCreated.Time.Equal(created.Time) || gotID != *id || gotName.Valid || gotScore != *score {
+		t.Errorf("Expected round trip, got %v %v %v %v", gotCreated.Time, gotID, gotName, gotScore)
+	}
+
+	if err := DecodeKeyset(token, &gotID); !errors.Is(err, ErrInvalidKeyset) {
+		t.Errorf("Expected ErrInvalidKeyset for count mismatch, got %v", err)
+	}
+	for _, bad := range []string{"!!", "bm90IGpzb24", "W1sicSIsIjEiXV0"} {
+		if err := DecodeKeyset(bad, &gotID); !errors.Is(err, ErrInvalidKeyset) {
+			t.Errorf("Expected ErrInvalidKeyset for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestKeysetWhere(t *testing.T) {
+	cols := []KeysetColumn{{Name: "created_at", Desc: true}, {Name: "id"}}
+	if got := KeysetOrderBy(cols); got != "created_at DESC NULLS LAST, id ASC NULLS LAST" {
+		t.Errorf("Unexpected ORDER BY: %s", got)
+	}
+
+	ts := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	where, args, err := KeysetWhere(cols, []driver.Valuer{NewCustomTime(ts), NewNullInt64(7)}, nil, 1)
+	want := "((created_at < $1 OR created_at IS NULL)) OR (created_at = $2 AND (id > $3 OR id IS NULL))"
+	if err != nil || where != want || !reflect.DeepEqual(args, []interface{}{ts, ts, int64(7)}) {
+		t.Errorf("Expected %s %v, got %s %v and error %v", want, []interface{}{ts, ts, int64(7)}, where, args, err)
+	}
+
+	where, args, _ = KeysetWhere(cols, []driver.Valuer{NullInt64{}, NewNullInt64(7)}, PlaceholderQuestion, 1)
+	if where != "created_at IS NULL AND (id > ? OR id IS NULL)" || !reflect.DeepEqual(args, []interface{}{int64(7)}) {
+		t.Errorf("Unexpected null keyset condition: %s %v", where, args)
+	}
+
+	where, args, _ = KeysetWhere(cols, []driver.Valuer{NullInt64{}, NullInt64{}}, nil, 1)
+	if where != "1 = 0" || args != nil {
+		t.Errorf("Expected no rows after an all-null key, got %s %v", where, args)
+	}
+
+	if _, _, err := KeysetWhere(cols, nil, nil, 1); err == nil {
+		t.Errorf("Expected error for value count mismatch, got nil")
+	}
+}
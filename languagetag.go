@@ -0,0 +1,175 @@
+// languagetag.go
+package octypes
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// LanguageTagMode selects how LocalizedText keys are checked when decoded
+// by Scan or UnmarshalJSON.
+type LanguageTagMode uint8
+
+const (
+	// LanguageTagsAsIs keeps keys unchanged. This is the default.
+	LanguageTagsAsIs LanguageTagMode = iota
+	// LanguageTagsCanonical rewrites well-formed BCP 47 keys in canonical
+	// case ("en-us" becomes "en-US") and keeps other keys unchanged.
+	LanguageTagsCanonical
+	// LanguageTagsStrict canonicalizes keys like LanguageTagsCanonical and
+	// fails with ErrInvalidLanguageTag if any key is not well-formed.
+	LanguageTagsStrict
+)
+
+// ErrInvalidLanguageTag is returned when decoding a LocalizedText with keys
+// that are not well-formed BCP 47 tags under LanguageTagsStrict.
+var ErrInvalidLanguageTag = errors.New("invalid language tag")
+
+// CanonicalLanguageTag reports whether tag is a well-formed BCP 47 language
+// tag and returns it in canonical case: lowercase language, title case
+// script and uppercase region, e.g. "zh-Hant-TW". '_' is accepted as a
+// separator and replaced by '-'. The primary language must have 2 or 3
+// letters: the 5 to 8 letter form allowed by the grammar is unused in
+// practice and mostly matches junk such as "english". Grandfathered tags
+// are not supported.
+func CanonicalLanguageTag(tag string) (string, bool) {
+	if tag == "" {
+		return "", false
+	}
+	subtags := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool { return r == '-' || r == '_' })
+	if strings.Count(tag, "-")+strings.Count(tag, "_") != len(subtags)-1 {
+		return "", false
+	}
+	for _, s := range subtags {
+		if !isAlnum(s) || len(s) > 8 {
+			return "", false
+		}
+	}
+
+	i := 0
+	if subtags[0] == "x" {
+		return canonicalPrivateUse(subtags)
+	}
+	lang := subtags[0]
+	if !isAlpha(lang) || len(lang) < 2 || len(lang) > 3 {
+		return "", false
+	}
+	i++
+	for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+		i++
+	}
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		i++
+	}
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && isDigits(subtags[i]))) {
+		subtags[i] = strings.ToUpper(subtags[i])
+		i++
+	}
+	for i < len(subtags) && isVariant(subtags[i]) {
+		i++
+	}
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		i++
+		n := 0
+		for ; i < len(subtags) && len(subtags[i]) >= 2; i++ {
+			n++
+		}
+		if n == 0 {
+			return "", false
+		}
+	}
+	if i < len(subtags) && subtags[i] == "x" {
+		private, ok := canonicalPrivateUse(subtags[i:])
+		if !ok {
+			return "", false
+		}
+		return strings.Join(subtags[:i], "-") + "-" + private, true
+	}
+	if i != len(subtags) {
+		return "", false
+	}
+	return strings.Join(subtags, "-"), true
+}
+
+// canonicalPrivateUse validates a private use sequence starting with "x".
+func canonicalPrivateUse(subtags []string) (string, bool) {
+	if len(subtags) < 2 {
+		return "", false
+	}
+	return strings.Join(subtags, "-"), true
+}
+
+// isVariant reports whether s is a variant subtag: 5 to 8 alphanumerics, or
+// a digit followed by 3 alphanumerics.
+func isVariant(s string) bool {
+	return len(s) >= 5 || (len(s) == 4 && s[0] >= '0' && s[0] <= '9')
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlnum reports whether the lowercased s is non-empty and alphanumeric.
+func isAlnum(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeTags applies the configured LanguageTagMode to the keys of lt.
+// When a canonicalized key collides with a key already in canonical form,
+// the latter wins; when several non-canonical keys fold to the same tag,
+// the smallest one wins, so the result does not depend on map order.
+func (lt LocalizedText) normalizeTags() (LocalizedText, error) {
+	if languageTagMode == LanguageTagsAsIs || lt == nil {
+		return lt, nil
+	}
+	var invalid []string
+	out := make(LocalizedText, len(lt))
+	for _, k := range slices.Sorted(maps.Keys(lt)) {
+		v := lt[k]
+		c, ok := CanonicalLanguageTag(k)
+		if !ok {
+			invalid = append(invalid, k)
+			out[k] = v
+			continue
+		}
+		if c != k {
+			if _, exists := lt[c]; exists {
+				continue
+			}
+			if _, exists := out[c]; exists {
+				continue
+			}
+		}
+		out[c] = v
+	}
+	if len(invalid) > 0 && languageTagMode == LanguageTagsStrict {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLanguageTag, invalid)
+	}
+	return out, nil
+}
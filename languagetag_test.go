@@ -0,0 +1,78 @@
+// languagetag_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCanonicalLanguageTag(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"en", "en", true},
+		{"en-us", "en-US", true},
+		{"EN_us", "en-US", true},
+		{"zh-hant-tw", "zh-Hant-TW", true},
+		{"es-419", "es-419", true},
+		{"sl-rozaj-biske", "sl-rozaj-biske", true},
+		{"de-CH-1901", "de-CH-1901", true},
+		{"zh-yue-HK", "zh-yue-HK", true},
+		{"en-US-u-ca-gregory", "en-US-u-ca-gregory", true},
+		{"en-x-Custom", "en-x-custom", true},
+		{"x-whatever", "x-whatever", true},
+		{"", "", false},
+		{"e", "", false},
+		{"english", "", false},
+		{"en-", "", false},
+		{"en--us", "", false},
+		{"en-u", "", false},
+		{"en-x", "", false},
+		{"title", "", false},
+		{"fr-FR-toolongvariant", "", false},
+		{"en US", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CanonicalLanguageTag(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Expected %q, %v for %q, got %q, %v", tt.want, tt.ok, tt.in, got, ok)
+		}
+	}
+}
+
+func TestLanguageTagMode(t *testing.T) {
+	defer SetLanguageTagMode(LanguageTagsAsIs)
+	src := `{"en-us":"Hi","fr":"Salut","??":"junk"}`
+
+	var lt LocalizedText
+	if err := json.Unmarshal([]byte(src), &lt); err != nil || lt["en-us"] != "Hi" {
+		t.Errorf("Expected keys unchanged by default, got %v and error %v", lt, err)
+	}
+
+	SetLanguageTagMode(LanguageTagsCanonical)
+	if err := lt.Scan(src); err != nil {
+		t.Errorf("Error scanning: %v", err)
+	}
+	if lt["en-US"] != "Hi" || lt["??"] != "junk" || len(lt) != 3 {
+		t.Errorf("Expected canonical keys with junk kept, got %v", lt)
+	}
+
+	if err := json.Unmarshal([]byte(`{"en-us":"old","en-US":"new"}`), &lt); err != nil || lt["en-US"] != "new" || len(lt) != 1 {
+		t.Errorf("Expected canonical key to win on collision, got %v and error %v", lt, err)
+	}
+	for i := 0; i < 20; i++ {
+		if err := json.Unmarshal([]byte(`{"en_US":"c","en-us":"b","EN-US":"a"}`), &lt); err != nil || lt["en-US"] != "a" || len(lt) != 1 {
+			t.Fatalf("Expected the smallest non-canonical key to win on collision, got %v and error %v", lt, err)
+		}
+	}
+
+	SetLanguageTagMode(LanguageTagsStrict)
+	if err := json.Unmarshal([]byte(src), &lt); !errors.Is(err, ErrInvalidLanguageTag) {
+		t.Errorf("Expected ErrInvalidLanguageTag, got %v", err)
+	}
+	if err := lt.Scan(`"en_gb"=>"Hello"`); err != nil || lt["en-GB"] != "Hello" {
+		t.Errorf("Expected canonical hstore key, got %v and error %v", lt, err)
+	}
+}
@@ -0,0 +1,124 @@
+// layout.go
+package octypes
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// LayoutField is a struct field as seen by the layout analysis.
+type LayoutField struct {
+	Name  string
+	Type  string
+	Size  int64
+	Align int64
+	// Offset is the field's offset in the layout being reported.
+	Offset int64
+	// Padding is the number of bytes wasted before the field.
+	Padding int64
+}
+
+// LayoutReport describes the memory layout of a struct and the layout
+// obtained by reordering its fields.
+type LayoutReport struct {
+	Name   string
+	Fields []LayoutField
+	// Size is the struct size and Padding the bytes of it wasted between
+	// and after fields.
+	Size    int64
+	Padding int64
+	// Suggested is the fields sorted by decreasing alignment, then size,
+	// and SuggestedSize the resulting struct size, never larger than Size.
+	Suggested     []LayoutField
+	SuggestedSize int64
+}
+
+// Savings returns the bytes per value saved by the suggested layout.
+func (r LayoutReport) Savings() int64 {
+	return r.Size - r.SuggestedSize
+}
+
+// String formats the report for humans, listing the suggested field order
+// when it is smaller.
+func (r LayoutReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d bytes, %d of padding\n", r.Name, r.Size, r.Padding)
+	for _, f := range r.Fields {
+		fmt.Fprintf(&b, "\t%4d %-20s %-24s size %d", f.Offset, f.Name, f.Type, f.Size)
+		if f.Padding > 0 {
+			fmt.Fprintf(&b, " (%d bytes of padding before)", f.Padding)
+		}
+		b.WriteByte('\n')
+	}
+	if r.Savings() > 0 {
+		fmt.Fprintf(&b, "reordered: %d bytes, saving %d per value\n", r.SuggestedSize, r.Savings())
+		for _, f := range r.Suggested {
+			fmt.Fprintf(&b, "\t%4d %-20s %s\n", f.Offset, f.Name, f.Type)
+		}
+	}
+	return b.String()
+}
+
+// Analyze reports the layout of struct type t, or of the struct a pointer
+// type points to, and suggests a field order minimizing padding. Structs
+// of octypes fields often waste space since every Null type ends with a
+// bool.
+func Analyze(t reflect.Type) (LayoutReport, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return LayoutReport{}, fmt.Errorf("%s is not a struct", t)
+	}
+	fields := make([]LayoutField, t.NumField())
+	for i := range fields {
+		f := t.Field(i)
+		fields[i] = LayoutField{Name: f.Name, Type: f.Type.String(), Size: int64(f.Type.Size()), Align: int64(f.Type.Align())}
+	}
+	return AnalyzeFields(t.String(), fields), nil
+}
+
+// AnalyzeFields is Analyze for fields described by their size and
+// alignment, for tools that get them from elsewhere than reflection, such
+// as go/types. Offsets and padding of fields are computed.
+func AnalyzeFields(name string, fields []LayoutField) LayoutReport {
+	r := LayoutReport{Name: name, Fields: slices.Clone(fields)}
+	r.Size, r.Padding = layoutFields(r.Fields)
+	r.Suggested = slices.Clone(fields)
+	slices.SortStableFunc(r.Suggested, func(a, b LayoutField) int {
+		if c := cmp.Compare(b.Align, a.Align); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Size, a.Size)
+	})
+	r.SuggestedSize, _ = layoutFields(r.Suggested)
+	if r.SuggestedSize >= r.Size {
+		r.Suggested, r.SuggestedSize = slices.Clone(r.Fields), r.Size
+	}
+	return r
+}
+
+// layoutFields sets the offsets and padding of fields laid out in order,
+// as the gc compiler does, and returns the struct size and total padding.
+func layoutFields(fields []LayoutField) (size, padding int64) {
+	var off, align int64 = 0, 1
+	for i := range fields {
+		f := &fields[i]
+		a := max(f.Align, 1)
+		aligned := (off + a - 1) / a * a
+		f.Offset, f.Padding = aligned, aligned-off
+		padding += f.Padding
+		off = aligned + f.Size
+		align = max(align, a)
+	}
+	// A trailing zero-size field gets a byte so its address stays inside
+	// the struct.
+	if n := len(fields); n > 0 && fields[n-1].Size == 0 && off > 0 {
+		off++
+	}
+	size = (off + align - 1) / align * align
+	return size, padding + size - off
+}
@@ -0,0 +1,85 @@
+// layout_test.go
+package octypes
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+type layoutWasteful struct {
+	Active  bool
+	ID      NullInt64
+	Deleted bool
+	Name    NullString
+	Flag    bool
+	Price   NullFloat64
+}
+
+type layoutTrailing struct {
+	ID    int64
+	Empty struct{}
+}
+
+func TestAnalyze(t *testing.T) {
+	r, err := Analyze(reflect.TypeOf(&layoutWasteful{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Size != int64(unsafe.Sizeof(layoutWasteful{})) {
+		t.Errorf("Expected size %d, got %d", unsafe.Sizeof(layoutWasteful{}), r.Size)
+	}
+	for i, f := range r.Fields {
+		if want := int64(reflect.TypeOf(layoutWasteful{}).Field(i).Offset); f.Offset != want {
+			t.Errorf("Expected %s at offset %d, got %d", f.Name, want, f.Offset)
+		}
+	}
+	if r.Padding == 0 || r.Savings() <= 0 {
+		t.Errorf("Expected padding and savings, got %+v", r)
+	}
+	if last := r.Suggested[len(r.Suggested)-1].Name; last != "Flag" {
+		t.Errorf("Expected the bools last, got %s", last)
+	}
+	if !strings.Contains(r.String(), "reordered:") {
+		t.Errorf("Expected a suggestion in %s", r)
+	}
+
+	r, _ = Analyze(reflect.TypeOf(layoutTrailing{}))
+	if r.Size != int64(unsafe.Sizeof(layoutTrailing{})) {
+		t.Errorf("Expected size %d, got %d", unsafe.Sizeof(layoutTrailing{}), r.Size)
+	}
+	r, _ = Analyze(reflect.TypeOf(NullString{}))
+	if r.Savings() != 0 || strings.Contains(r.String(), "reordered:") {
+		t.Errorf("Expected no suggestion for a tight struct, got %s", r)
+	}
+	if _, err := Analyze(reflect.TypeOf(0)); err == nil {
+		t.Errorf("Expected an error for a non-struct")
+	}
+}
+
+func TestPublicTypesLayout(t *testing.T) {
+	types := []struct {
+		public, embedded interface{}
+	}{
+		{NullString{}, sql.NullString{}},
+		{NullInt64{}, sql.NullInt64{}},
+		{NullFloat64{}, sql.NullFloat64{}},
+		{NullBool{}, sql.NullBool{}},
+		{CustomTime{}, sql.NullTime{}},
+	}
+	for _, tt := range types {
+		pt, et := reflect.TypeOf(tt.public), reflect.TypeOf(tt.embedded)
+		if pt.NumField() != 1 || !pt.Field(0).Anonymous || pt.Field(0).Type != et {
+			t.Errorf("Expected %s to only embed %s", pt, et)
+		}
+		r, err := Analyze(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Size != int64(et.Size()) || r.Savings() != 0 {
+			t.Errorf("Expected %s to have the %d byte layout of %s, got %+v", pt, et.Size(), et, r)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// lazytime.go
+package octypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"time"
+)
+
+// LazyTime is a CustomTime decoded on first use. UnmarshalJSON only keeps
+// a copy of the JSON token, and the time is parsed, with the same rules as
+// CustomTime.UnmarshalJSON, when Get, Time or a method that needs it is
+// called. Ingestion pipelines decoding millions of records but reading few
+// of their times skip the parsing of the others.
+//
+// Parsing stores its result in the value, so a LazyTime is not safe for
+// concurrent use until Get has been called once. Copies made before that
+// parse independently.
+//
+// The zero LazyTime is null.
+type LazyTime struct {
+	raw []byte // the token not parsed yet, if any
+	ct  CustomTime
+	err error
+}
+
+// NewLazyTime returns an already decoded LazyTime holding ct.
+func NewLazyTime(ct CustomTime) LazyTime {
+	return LazyTime{ct: ct}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It does not
+// parse b; errors in the time are reported by Get.
+func (lt *LazyTime) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*lt = LazyTime{}
+		return nil
+	}
+	*lt = LazyTime{raw: bytes.Clone(b)}
+	return nil
+}
+
+// Get parses the time if needed and returns it.
+func (lt *LazyTime) Get() (CustomTime, error) {
+	if lt.raw != nil {
+		lt.err = lt.ct.UnmarshalJSON(lt.raw)
+		lt.raw = nil
+	}
+	return lt.ct, lt.err
+}
+
+// Time returns the parsed time, or the zero time if it is null or invalid.
+func (lt *LazyTime) Time() time.Time {
+	ct, _ := lt.Get()
+	return ct.Time
+}
+
+// Raw returns the JSON token kept by UnmarshalJSON, or nil once the time
+// has been parsed.
+func (lt *LazyTime) Raw() []byte {
+	return lt.raw
+}
+
+// MarshalJSON implements the json.Marshaler interface. It parses the time,
+// without keeping the result, so the output follows the configured
+// CustomTime format.
+func (lt LazyTime) MarshalJSON() ([]byte, error) {
+	ct, err := lt.Get()
+	if err != nil {
+		return nil, err
+	}
+	return ct.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface. Database values are decoded
+// immediately.
+func (lt *LazyTime) Scan(value interface{}) error {
+	*lt = LazyTime{}
+	return lt.ct.Scan(value)
+}
+
+// Value implements the driver.Valuer interface.
+func (lt LazyTime) Value() (driver.Value, error) {
+	ct, err := lt.Get()
+	if err != nil {
+		return nil, err
+	}
+	return ct.Value()
+}
@@ -0,0 +1,134 @@
+// lazytime_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLazyTime(t *testing.T) {
+	var rec struct {
+		Created LazyTime `json:"created"`
+		Deleted LazyTime `json:"deleted"`
+	}
+	data := []byte(`{"created":"2023-06-15T10:20:30Z","deleted":null}`)
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatal(err)
+	}
+	data[12] = 'X' // the token must have been copied
+	if string(rec.Created.Raw()) != `"2023-06-15T10:20:30Z"` {
+		t.Errorf("Expected the raw token to be kept, got %s", rec.Created.Raw())
+	}
+	if !rec.Deleted.IsNull() || rec.Deleted.Raw() != nil {
+		t.Errorf("Expected a null time without raw token")
+	}
+
+	ct, err := rec.Created.Get()
+	want := time.Date(2023, 6, 15, 10, 20, 30, 0, time.UTC)
+	if err != nil || !ct.Valid || !ct.Time.Equal(want) {
+		t.Errorf("Expected %v, got %v, %v", want, ct.Time, err)
+	}
+	if rec.Created.Raw() != nil || !rec.Created.Time().Equal(want) {
+		t.Errorf("Expected the parsed time to be kept")
+	}
+
+	out, err := json.Marshal(rec)
+	created, _ := NewCustomTime(want).MarshalJSON()
+	expected := `{"created":` + string(created) + `,"deleted":null}`
+	if err != nil || string(out) != expected {
+		t.Errorf("Expected %s, got %s, %v", expected, out, err)
+	}
+}
+
+func TestLazyTimeError(t *testing.T) {
+	var lt LazyTime
+	if err := json.Unmarshal([]byte(`"20000-01-01T00:00:00Z"`), &lt); err != nil {
+		t.Fatalf("Expected parsing to be deferred, got %v", err)
+	}
+	if lt.IsNull() || lt.IsValid() || lt.Raw() == nil {
+		t.Errorf("Expected an unparsed token to be neither null nor valid, and to stay unparsed")
+	}
+	if _, err := lt.Get(); err == nil {
+		t.Errorf("Expected an error from Get")
+	}
+	if !lt.IsNull() || !lt.Time().IsZero() {
+		t.Errorf("Expected an invalid time to be null")
+	}
+	if _, err := lt.Value(); err == nil {
+		t.Errorf("Expected an error from Value")
+	}
+
+	json.Unmarshal([]byte(`""`), &lt)
+	if !lt.IsNull() || lt.Raw() == nil {
+		t.Errorf("Expected an empty token to be null without parsing")
+	}
+
+	lt = NewLazyTime(*NewCustomTime(time.Unix(0, 0)))
+	if _, err := lt.Get(); err != nil || lt.IsNull() {
+		t.Errorf("Expected a valid time, got %v", err)
+	}
+	if err := lt.Scan("bad"); err == nil || errors.Is(err, ErrTimeOutOfRange) {
+		t.Errorf("Expected a parse error from Scan, got %v", err)
+	}
+}
+
+func BenchmarkLazyTimeUnmarshalJSON(b *testing.B) {
+	data := []byte(`"2023-06-15T10:20:30.123456789Z"`)
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		var lt LazyTime
+		for i := 0; i < b.N; i++ {
+			lt.UnmarshalJSON(data)
+		}
+	})
+	b.Run("CustomTime", func(b *testing.B) {
+		b.ReportAllocs()
+		var ct CustomTime
+		for i := 0; i < b.N; i++ {
+			ct.UnmarshalJSON(data)
+		}
+	})
+}
+
+func TestLazyTimeNullableMethods(t *testing.T) {
+	var lt LazyTime
+	if err := json.Unmarshal([]byte(`"2023-06-15T10:20:30Z"`), &lt); err != nil {
+		t.Fatal(err)
+	}
+	var n Nullable = lt
+	if n.IsNull() || !lt.IsValid() || lt.Raw() == nil {
+		t.Errorf("Expected a valid time left unparsed by IsNull")
+	}
+
+	parsed := NewLazyTime(*NewCustomTime(time.Date(2023, 6, 15, 12, 20, 30, 0, time.FixedZone("X", 7200))))
+	if !lt.Equal(parsed) || lt.Equal(LazyTime{}) || !(LazyTime{}).Equal(LazyTime{}) {
+		t.Errorf("Expected Equal to compare instants")
+	}
+
+	c := lt.Clone()
+	c.Raw()[1] = 'X'
+	if lt.Raw()[1] != '2' {
+		t.Errorf("Expected Clone not to share the raw token")
+	}
+
+	if got := parsed.GoString(); got != "octypes.NewLazyTime("+parsed.ct.GoString()+")" {
+		t.Errorf("Unexpected GoString %s", got)
+	}
+	if got := (LazyTime{}).GoString(); got != "octypes.LazyTime{}" {
+		t.Errorf("Expected octypes.LazyTime{}, got %s", got)
+	}
+
+	lt.Reset()
+	if !lt.IsNull() || lt.Raw() != nil {
+		t.Errorf("Expected null LazyTime after Reset")
+	}
+
+	var s struct {
+		At LazyTime `validate:"required"`
+	}
+	if err := Validate(&s); err == nil {
+		t.Errorf("Expected required error for a null LazyTime")
+	}
+}
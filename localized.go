@@ -0,0 +1,273 @@
+// localized.go
+package octypes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrValueTooLong is returned when decoding a LocalizedText value longer
+// than the limit set with SetLocalizedTextMaxLength.
+var ErrValueTooLong = errors.New("localized text value too long")
+
+// sanitize applies the configured sanitizer and length limit to the values
+// of lt in place.
+func (lt LocalizedText) sanitize() error {
+	if textSanitizer == nil && textMaxLength <= 0 {
+		return nil
+	}
+	for k, v := range lt {
+		if textSanitizer != nil {
+			v = textSanitizer(k, v)
+			lt[k] = v
+		}
+		if textMaxLength > 0 && utf8.RuneCountInString(v) > textMaxLength {
+			return fmt.Errorf("%w: %q has %d runes, limit is %d", ErrValueTooLong, k, utf8.RuneCountInString(v), textMaxLength)
+		}
+	}
+	return nil
+}
+
+// StripControlChars removes Unicode control characters other than '\n' and
+// '\t' from value. Its signature matches SetLocalizedTextSanitizer.
+func StripControlChars(lang, value string) string {
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// Resolve returns the best value of lt for the BCP 47 tag lang, walking the
+// fallback chain lang, its parents ("zh-Hant-TW", "zh-Hant", "zh"), the
+// language set with SetDefaultLanguage, then any language, taking the
+// smallest tag for determinism. Tags match case-insensitively and '_' is
+// treated as '-'. Empty values count as missing. tag is the key of lt that
+// matched; ok is false if lt holds no value at all.
+func (lt LocalizedText) Resolve(lang string) (value, tag string, ok bool) {
+	for lang != "" {
+		if tag, ok = lt.lookupTag(lang); ok {
+			return lt[tag], tag, true
+		}
+		i := strings.LastIndexAny(lang, "-_")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	if defaultLanguage != "" {
+		if tag, ok = lt.lookupTag(defaultLanguage); ok {
+			return lt[tag], tag, true
+		}
+	}
+	keys := make([]string, 0, len(lt))
+	for k, v := range lt {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return "", "", false
+	}
+	sort.Strings(keys)
+	return lt[keys[0]], keys[0], true
+}
+
+// lookupTag returns the key of lt matching lang with a non-empty value.
+func (lt LocalizedText) lookupTag(lang string) (string, bool) {
+	if lt[lang] != "" {
+		return lang, true
+	}
+	for k, v := range lt {
+		if v != "" && equalTags(k, lang) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// equalTags reports whether two language tags are equal ignoring case and
+// the '-' or '_' separator.
+func equalTags(a, b string) bool {
+	return strings.EqualFold(strings.ReplaceAll(a, "_", "-"), strings.ReplaceAll(b, "_", "-"))
+}
+
+// MergeStrategy selects how LocalizedText.Merge resolves keys present in
+// both maps with different values.
+type MergeStrategy uint8
+
+const (
+	// MergeKeepExisting keeps the receiver's value.
+	MergeKeepExisting MergeStrategy = iota
+	// MergeOverwrite takes the other map's value.
+	MergeOverwrite
+	// MergeErrorOnConflict fails with ErrMergeConflict.
+	MergeErrorOnConflict
+)
+
+// ErrMergeConflict is returned by LocalizedText.Merge with
+// MergeErrorOnConflict when both maps hold different values for a key.
+var ErrMergeConflict = errors.New("conflicting translations")
+
+// Merge returns a new LocalizedText holding the keys of lt and other, with
+// conflicts resolved by strategy. Neither map is modified. The result is
+// nil only if both maps are nil.
+func (lt LocalizedText) Merge(other LocalizedText, strategy MergeStrategy) (LocalizedText, error) {
+	if lt == nil && other == nil {
+		return nil, nil
+	}
+	out := make(LocalizedText, len(lt)+len(other))
+	for k, v := range lt {
+		out[k] = v
+	}
+	var conflicts []string
+	for k, v := range other {
+		existing, ok := out[k]
+		if ok && existing != v {
+			switch strategy {
+			case MergeKeepExisting:
+				continue
+			case MergeErrorOnConflict:
+				conflicts = append(conflicts, k)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("%w: %q", ErrMergeConflict, conflicts)
+	}
+	return out, nil
+}
+
+// TranslationDiff reports how a LocalizedText differs from a reference set
+// of languages. All slices are sorted.
+type TranslationDiff struct {
+	// Missing lists reference languages without a key.
+	Missing []string `json:"missing"`
+	// Empty lists reference languages whose value is blank.
+	Empty []string `json:"empty"`
+	// Extra lists keys that are not reference languages.
+	Extra []string `json:"extra"`
+}
+
+// Complete reports whether every reference language has a value.
+func (d TranslationDiff) Complete() bool {
+	return len(d.Missing) == 0 && len(d.Empty) == 0
+}
+
+// Diff compares the keys of lt with the reference languages. Tags match as
+// in Resolve, and values holding only whitespace count as empty.
+func (lt LocalizedText) Diff(reference []string) TranslationDiff {
+	var d TranslationDiff
+	matched := make(map[string]bool, len(lt))
+	for _, lang := range reference {
+		key, ok := lt.findTag(lang)
+		if !ok {
+			d.Missing = append(d.Missing, lang)
+			continue
+		}
+		matched[key] = true
+		if strings.TrimSpace(lt[key]) == "" {
+			d.Empty = append(d.Empty, lang)
+		}
+	}
+	for k := range lt {
+		if !matched[k] {
+			d.Extra = append(d.Extra, k)
+		}
+	}
+	sort.Strings(d.Missing)
+	sort.Strings(d.Empty)
+	sort.Strings(d.Extra)
+	return d
+}
+
+// findTag returns the key of lt matching lang, whatever its value.
+func (lt LocalizedText) findTag(lang string) (string, bool) {
+	if _, ok := lt[lang]; ok {
+		return lang, true
+	}
+	for k := range lt {
+		if equalTags(k, lang) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// Completeness returns the percentage of required languages that have a
+// value in lt and the sorted list of those that do not, counting blank
+// values as missing. No required languages means 100 percent.
+func (lt LocalizedText) Completeness(required []string) (percent float64, missing []string) {
+	if len(required) == 0 {
+		return 100, nil
+	}
+	d := lt.Diff(required)
+	missing = append(d.Missing, d.Empty...)
+	sort.Strings(missing)
+	return 100 * float64(len(required)-len(missing)) / float64(len(required)), missing
+}
+
+// CompletenessReport aggregates Completeness over many LocalizedText values.
+type CompletenessReport struct {
+	// Texts is the number of values inspected.
+	Texts int `json:"texts"`
+	// Complete is the number of values with every required language.
+	Complete int `json:"complete"`
+	// Percent is the percentage of required translations present overall.
+	Percent float64 `json:"percent"`
+	// Missing counts, per required language, the values lacking it.
+	Missing map[string]int `json:"missing"`
+}
+
+// CompletenessOf aggregates the completeness of texts for the required
+// languages.
+func CompletenessOf(texts []LocalizedText, required []string) CompletenessReport {
+	r := CompletenessReport{Texts: len(texts), Percent: 100, Missing: make(map[string]int)}
+	total, present := len(texts)*len(required), 0
+	for _, lt := range texts {
+		_, missing := lt.Completeness(required)
+		if len(missing) == 0 {
+			r.Complete++
+		}
+		for _, lang := range missing {
+			r.Missing[lang]++
+		}
+		present += len(required) - len(missing)
+	}
+	if total > 0 {
+		r.Percent = 100 * float64(present) / float64(total)
+	}
+	return r
+}
+
+// Filter returns a new LocalizedText holding only the keys of lt matching
+// one of langs or one of their parents, so Filter("fr-CA", "en") keeps
+// "fr-CA", "fr" and "en". Tags match as in Resolve. Marshal the result to
+// send only the languages a client needs.
+func (lt LocalizedText) Filter(langs ...string) LocalizedText {
+	if lt == nil {
+		return nil
+	}
+	out := make(LocalizedText, len(langs))
+	for _, lang := range langs {
+		for lang != "" {
+			if k, ok := lt.findTag(lang); ok {
+				out[k] = lt[k]
+			}
+			i := strings.LastIndexAny(lang, "-_")
+			if i < 0 {
+				break
+			}
+			lang = lang[:i]
+		}
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+// localized_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLocalizedTextResolve(t *testing.T) {
+	lt := LocalizedText{"fr": "Bonjour", "en": "Hello", "pt_BR": "Olá", "de": ""}
+	tests := []struct {
+		lang, value, tag string
+	}{
+		{"fr", "Bonjour", "fr"},
+		{"fr-CA", "Bonjour", "fr"},
+		{"pt-br", "Olá", "pt_BR"},
+		{"EN", "Hello", "en"},
+		{"de", "Bonjour", "fr"},
+		{"ja", "Bonjour", "fr"},
+	}
+	SetDefaultLanguage("")
+	if v, tag, ok := lt.Resolve("ja"); !ok || tag != "en" || v != "Hello" {
+		t.Errorf("Expected smallest tag en without default, got %q %q %v", v, tag, ok)
+	}
+
+	SetDefaultLanguage("fr")
+	defer SetDefaultLanguage("")
+	for _, tt := range tests {
+		v, tag, ok := lt.Resolve(tt.lang)
+		if !ok || v != tt.value || tag != tt.tag {
+			t.Errorf("Expected %q from %q for %s, got %q from %q", tt.value, tt.tag, tt.lang, v, tag)
+		}
+	}
+
+	if _, _, ok := (LocalizedText{"en": ""}).Resolve("en"); ok {
+		t.Errorf("Expected ok false for empty values")
+	}
+	if _, _, ok := LocalizedText(nil).Resolve("en"); ok {
+		t.Errorf("Expected ok false for nil LocalizedText")
+	}
+}
+
+func TestLocalizedTextMerge(t *testing.T) {
+	defaults := LocalizedText{"en": "Save", "fr": "Enregistrer"}
+	tenant := LocalizedText{"en": "Store", "de": "Speichern", "fr": "Enregistrer"}
+
+	got, err := defaults.Merge(tenant, MergeKeepExisting)
+	if err != nil || got["en"] != "Save" || got["de"] != "Speichern" || len(got) != 3 {
+		t.Errorf("Expected existing values kept, got %v and error %v", got, err)
+	}
+	got, err = defaults.Merge(tenant, MergeOverwrite)
+	if err != nil || got["en"] != "Store" || got["fr"] != "Enregistrer" || len(got) != 3 {
+		t.Errorf("Expected tenant values, got %v and error %v", got, err)
+	}
+	if _, err := defaults.Merge(tenant, MergeErrorOnConflict); !errors.Is(err, ErrMergeConflict) {
+		t.Errorf("Expected ErrMergeConflict, got %v", err)
+	}
+	if defaults["en"] != "Save" || len(defaults) != 2 {
+		t.Errorf("Expected receiver unchanged, got %v", defaults)
+	}
+
+	got, err = LocalizedText(nil).Merge(LocalizedText{"en": "Hi"}, MergeErrorOnConflict)
+	if err != nil || got["en"] != "Hi" {
+		t.Errorf("Expected merge into nil, got %v and error %v", got, err)
+	}
+	if got, _ := LocalizedText(nil).Merge(nil, MergeOverwrite); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+}
+
+func TestLocalizedTextDiff(t *testing.T) {
+	lt := LocalizedText{"en": "Hello", "fr_fr": " ", "es": "Hola", "it": "Ciao"}
+	d := lt.Diff([]string{"en", "fr-FR", "de", "ja"})
+	want := TranslationDiff{
+		Missing: []string{"de", "ja"},
+		Empty:   []string{"fr-FR"},
+		Extra:   []string{"es", "it"},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("Expected %+v, got %+v", want, d)
+	}
+	if d.Complete() {
+		t.Errorf("Expected incomplete diff")
+	}
+	if d := lt.Diff([]string{"en", "es"}); !d.Complete() {
+		t.Errorf("Expected complete diff, got %+v", d)
+	}
+}
+
+func TestLocalizedTextSanitizeAndLimit(t *testing.T) {
+	SetLocalizedTextSanitizer(StripControlChars)
+	defer SetLocalizedTextSanitizer(nil)
+
+	var lt LocalizedText
+	if err := json.Unmarshal([]byte(`{"en":"Hi\u0000 there\u0007\nbye"}`), &lt); err != nil {
+		t.Fatalf("Error unmarshalling: %v", err)
+	}
+	if lt["en"] != "Hi there\nbye" {
+		t.Errorf("Expected control chars stripped, got %q", lt["en"])
+	}
+
+	SetLocalizedTextMaxLength(5)
+	defer SetLocalizedTextMaxLength(0)
+	if err := lt.Scan(`{"fr":"héllo"}`); err != nil || lt["fr"] != "héllo" {
+		t.Errorf("Expected 5 runes to be accepted, got %v and error %v", lt, err)
+	}
+	if err := lt.Scan(`{"fr":"bonjour"}`); !errors.Is(err, ErrValueTooLong) {
+		t.Errorf("Expected ErrValueTooLong, got %v", err)
+	}
+	if err := lt.Scan("{\"fr\":\"\\u0001\\u0002abcde\"}"); err != nil {
+		t.Errorf("Expected limit to apply after sanitizing, got %v", err)
+	}
+}
+
+func TestLocalizedTextCompleteness(t *testing.T) {
+	required := []string{"en", "fr", "de", "es"}
+	pct, missing := LocalizedText{"en": "Hi", "fr": "Salut", "de": " "}.Completeness(required)
+	if pct != 50 || !reflect.DeepEqual(missing, []string{"de", "es"}) {
+		t.Errorf("Expected 50%% and [de es], got %v%% and %v", pct, missing)
+	}
+	if pct, missing := LocalizedText(nil).Completeness(nil); pct != 100 || missing != nil {
+		t.Errorf("Expected 100%% without required languages, got %v%% and %v", pct, missing)
+	}
+
+	r := CompletenessOf([]LocalizedText{
+		{"en": "a", "fr": "b"},
+		{"en": "a"},
+		nil,
+	}, []string{"en", "fr"})
+	want := CompletenessReport{Texts: 3, Complete: 1, Percent: 50, Missing: map[string]int{"fr": 2, "en": 1}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Expected %+v, got %+v", want, r)
+	}
+}
+
+func TestLocalizedTextFilter(t *testing.T) {
+	lt := LocalizedText{"en": "Hi", "fr": "Salut", "fr-CA": "Allo", "de": "Hallo", "ja": "こんにちは"}
+	got := lt.Filter("fr_ca", "en")
+	want := LocalizedText{"fr-CA": "Allo", "fr": "Salut", "en": "Hi"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	b, _ := json.Marshal(lt.Filter("de-AT"))
+	if string(b) != `{"de":"Hallo"}` {
+		t.Errorf("Expected only de, got %s", b)
+	}
+	if got := LocalizedText(nil).Filter("en"); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+}
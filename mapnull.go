@@ -0,0 +1,67 @@
+// mapnull.go
+package octypes
+
+import (
+	"database/sql"
+	"time"
+)
+
+// nullOf is implemented by the nullable wrappers N of a Go type T.
+type nullOf[N, T any] interface {
+	Nullable
+	Ptr() *T
+	withValue(v T) N
+}
+
+// MapNull returns null if n is null, and otherwise a valid value holding
+// f applied to the value of n:
+//
+//	upper := octypes.MapNull(ns, strings.ToUpper)
+//	later := octypes.MapNull(ct, func(t time.Time) time.Time { return t.Add(time.Hour) })
+func MapNull[N nullOf[N, T], T any](n N, f func(T) T) N {
+	p := n.Ptr()
+	if p == nil {
+		return n
+	}
+	return n.withValue(f(*p))
+}
+
+// Then returns null if n is null, and otherwise the result of f applied to
+// the value of n, which may itself be null. It chains steps that can fail,
+// such as lookups.
+func Then[N nullOf[N, T], T any](n N, f func(T) N) N {
+	p := n.Ptr()
+	if p == nil {
+		return n
+	}
+	return f(*p)
+}
+
+func (ns NullString) withValue(s string) NullString {
+	return NullString{sql.NullString{String: s, Valid: true}}
+}
+
+func (ni NullInt64) withValue(i int64) NullInt64 {
+	return *NewNullInt64(i)
+}
+
+func (nb NullBool) withValue(b bool) NullBool {
+	return *NewNullBool(b)
+}
+
+func (nf NullFloat64) withValue(f float64) NullFloat64 {
+	return *NewNullFloat64(f)
+}
+
+func (ct CustomTime) withValue(t time.Time) CustomTime {
+	return *NewCustomTime(t)
+}
+
+// withValue keeps the encoding of nb.
+func (nb NullBytes) withValue(b []byte) NullBytes {
+	return NullBytes{Bytes: b, Valid: true, Encoding: nb.Encoding}
+}
+
+func (c Composite[T]) withValue(v T) Composite[T] {
+	return *NewComposite(v)
+}
@@ -0,0 +1,54 @@
+// mapnull_test.go
+package octypes
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapNull(t *testing.T) {
+	if got := MapNull(*NewNullString("abc"), strings.ToUpper); got.String != "ABC" || !got.Valid {
+		t.Errorf("Expected ABC, got %v", got)
+	}
+	if got := MapNull(NullString{}, strings.ToUpper); got.Valid {
+		t.Errorf("Expected null, got %v", got)
+	}
+	empty := ""
+	if got := MapNull(*NewNullStringFromPtr(&empty), strings.TrimSpace); !got.Valid {
+		t.Errorf("Expected valid empty string, got %v", got)
+	}
+	if got := MapNull(*NewNullInt64(2), func(i int64) int64 { return i * 10 }); got.Int64 != 20 {
+		t.Errorf("Expected 20, got %v", got)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := MapNull(*NewCustomTime(start), func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }); got.Time.Day() != 2 {
+		t.Errorf("Expected next day, got %v", got)
+	}
+	nb := MapNull(*NewNullBytesWithEncoding([]byte("a"), BytesEncodingHex), func(b []byte) []byte { return append(b, 'b') })
+	if string(nb.Bytes) != "ab" || nb.Encoding != BytesEncodingHex {
+		t.Errorf("Expected ab in hex, got %+v", nb)
+	}
+	if got := MapNull(*NewComposite(1), func(i int) int { return i + 1 }); got.V != 2 {
+		t.Errorf("Expected 2, got %v", got)
+	}
+}
+
+func TestThen(t *testing.T) {
+	lookup := func(id int64) NullInt64 {
+		if id == 1 {
+			return *NewNullInt64(100)
+		}
+		return NullInt64{}
+	}
+	if got := Then(*NewNullInt64(1), lookup); got.Int64 != 100 {
+		t.Errorf("Expected 100, got %v", got)
+	}
+	if got := Then(*NewNullInt64(2), lookup); got.Valid {
+		t.Errorf("Expected null from f, got %v", got)
+	}
+	called := false
+	if got := Then(NullInt64{}, func(int64) NullInt64 { called = true; return NullInt64{} }); got.Valid || called {
+		t.Errorf("Expected null without calling f, got %v", got)
+	}
+}
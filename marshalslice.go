@@ -0,0 +1,226 @@
+// marshalslice.go
+package octypes
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSONAppender is implemented by the octypes types that can append their
+// JSON encoding to a buffer, which MarshalSlice uses instead of
+// MarshalJSON to avoid a buffer per value. MarshalSlice only uses it for
+// the octypes types themselves, not for types that embed one.
+type JSONAppender interface {
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+var jsonAppenderType = reflect.TypeOf((*JSONAppender)(nil)).Elem()
+
+// appenderTypes are the octypes types implementing JSONAppender.
+var appenderTypes = map[reflect.Type]bool{
+	reflect.TypeOf(NullString{}):    true,
+	reflect.TypeOf(NullInt64{}):     true,
+	reflect.TypeOf(NullFloat64{}):   true,
+	reflect.TypeOf(NullBool{}):      true,
+	reflect.TypeOf(CustomTime{}):    true,
+	reflect.TypeOf(IntDictionary{}): true,
+}
+
+// isOctypesAppender reports whether t is an octypes type with an
+// AppendJSON method. Other types implementing JSONAppender, such as types
+// embedding an octypes type, may define their own MarshalJSON that the
+// promoted AppendJSON would bypass.
+func isOctypesAppender(t reflect.Type) bool {
+	return appenderTypes[t]
+}
+
+// MarshalSlice appends the JSON array of items, a slice or array, to dst
+// and returns the extended buffer. The output is the same as
+// json.Marshal(items), but struct elements are encoded field by field
+// into the one buffer, with octypes fields appended directly, which avoids
+// the per-value overhead of json.Marshal on large array responses.
+//
+// Structs with embedded fields or json tag options, and other element
+// types, fall back to json.Marshal for the elements or fields concerned.
+func MarshalSlice(dst []byte, items interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(items)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return append(dst, "null"...), nil
+		}
+	case reflect.Array:
+	default:
+		return dst, fmt.Errorf("MarshalSlice: %T is not a slice", items)
+	}
+	enc := sliceEncoderFor(rv.Type().Elem())
+	dst = append(dst, '[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		var err error
+		if dst, err = enc(dst, rv.Index(i)); err != nil {
+			return dst, fmt.Errorf("MarshalSlice: element %d: %w", i, err)
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+// sliceEncoder appends the JSON encoding of v to dst.
+type sliceEncoder func(dst []byte, v reflect.Value) ([]byte, error)
+
+var sliceEncoderCache sync.Map // map[reflect.Type]sliceEncoder
+
+// sliceEncoderFor returns the encoder of values of type t.
+func sliceEncoderFor(t reflect.Type) sliceEncoder {
+	if cached, ok := sliceEncoderCache.Load(t); ok {
+		return cached.(sliceEncoder)
+	}
+	enc := newSliceEncoder(t)
+	sliceEncoderCache.Store(t, enc)
+	return enc
+}
+
+func newSliceEncoder(t reflect.Type) sliceEncoder {
+	switch {
+	case t.Kind() == reflect.Pointer:
+		elem := sliceEncoderFor(t.Elem())
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			if v.IsNil() {
+				return append(dst, "null"...), nil
+			}
+			return elem(dst, v.Elem())
+		}
+	case isOctypesAppender(t):
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			// A pointer fits in an interface without allocating.
+			if v.CanAddr() {
+				return v.Addr().Interface().(JSONAppender).AppendJSON(dst)
+			}
+			return v.Interface().(JSONAppender).AppendJSON(dst)
+		}
+	case implementsEither(t, jsonMarshalerType) || implementsEither(t, textMarshalerType):
+		return marshalEncoder
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if enc, ok := newStructEncoder(t); ok {
+			return enc
+		}
+	case reflect.String:
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			return appendJSONString(dst, v.String()), nil
+		}
+	case reflect.Bool:
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			return strconv.AppendBool(dst, v.Bool()), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			return appendInt(dst, v.Int()), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			return strconv.AppendUint(dst, v.Uint(), 10), nil
+		}
+	case reflect.Float64:
+		return func(dst []byte, v reflect.Value) ([]byte, error) {
+			return appendJSONFloat(dst, v.Float())
+		}
+	}
+	return marshalEncoder
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// implementsEither reports whether t or a pointer to t implements iface.
+func implementsEither(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// marshalEncoder encodes v with json.Marshal. Addressable values are
+// passed by pointer so that MarshalJSON methods with pointer receivers are
+// used, as encoding/json does for slice elements.
+func marshalEncoder(dst []byte, v reflect.Value) ([]byte, error) {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	b, err := json.Marshal(v.Interface())
+	return append(dst, b...), err
+}
+
+// structField is a field encoded by a struct encoder. key is the encoded
+// `"name":` prefix.
+type structField struct {
+	index int
+	key   []byte
+	enc   sliceEncoder
+}
+
+// newStructEncoder returns an encoder writing the fields of struct type t
+// in declaration order, or false if t uses features it does not handle:
+// embedded fields, whose promotion rules are left to encoding/json, and
+// json tag options such as omitempty and string.
+func newStructEncoder(t reflect.Type) (sliceEncoder, bool) {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			return nil, false
+		}
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if opts != "" {
+			return nil, false
+		}
+		if name == "" {
+			name = f.Name
+		}
+		key := append(appendJSONString(nil, name), ':')
+		// Structs, slices and maps are left to json.Marshal, which also
+		// avoids recursing into self-referential types.
+		enc := marshalEncoder
+		switch f.Type.Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
+			if isOctypesAppender(f.Type) {
+				enc = sliceEncoderFor(f.Type)
+			}
+		case reflect.Pointer:
+			if e := f.Type.Elem().Kind(); (e != reflect.Struct && e != reflect.Pointer) || isOctypesAppender(f.Type.Elem()) {
+				enc = sliceEncoderFor(f.Type)
+			}
+		default:
+			enc = sliceEncoderFor(f.Type)
+		}
+		fields = append(fields, structField{index: i, key: key, enc: enc})
+	}
+	return func(dst []byte, v reflect.Value) ([]byte, error) {
+		dst = append(dst, '{')
+		for i, f := range fields {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, f.key...)
+			var err error
+			if dst, err = f.enc(dst, v.Field(f.index)); err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, '}'), nil
+	}, true
+}
@@ -0,0 +1,144 @@
+// marshalslice_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type sliceRow struct {
+	ID      NullInt64     `json:"id"`
+	Name    NullString    `json:"name"`
+	Price   NullFloat64   `json:"price"`
+	Active  NullBool      `json:"active"`
+	Created CustomTime    `json:"created"`
+	Deleted *CustomTime   `json:"deleted"`
+	Title   LocalizedText `json:"title"`
+	Counts  IntDictionary `json:"counts"`
+	Lazy    LazyTime      `json:"lazy"`
+	Plain   int
+	Small   uint8
+	Ratio   float64
+	Text    string
+	Tags    []string
+	Ptr     *int
+	When    time.Time
+	Next    *sliceRow
+	hidden  string
+	Skipped string `json:"-"`
+}
+
+type sliceEmbedded struct {
+	sliceRow
+	Extra NullString `json:"extra,omitempty"`
+}
+
+// customName embeds NullString, promoting AppendJSON, but has its own
+// MarshalJSON.
+type customName struct {
+	NullString
+}
+
+func (customName) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
+func TestMarshalSliceEmbeddedAppender(t *testing.T) {
+	for typ := range appenderTypes {
+		if !typ.Implements(jsonAppenderType) {
+			t.Errorf("Expected %s to implement JSONAppender", typ)
+		}
+	}
+	type row struct {
+		N customName
+		P *customName
+		A JSONAppender
+	}
+	items := []interface{}{
+		[]customName{{*NewNullString("x")}},
+		[]row{{N: customName{*NewNullString("x")}, P: &customName{}, A: NewNullInt64(1)}, {}},
+	}
+	for _, it := range items {
+		want, _ := json.Marshal(it)
+		for _, parallel := range []bool{false, true} {
+			var got []byte
+			var err error
+			if parallel {
+				got, err = MarshalSliceParallel(nil, it, ParallelOptions{Threshold: 1, Workers: 2})
+			} else {
+				got, err = MarshalSlice(nil, it)
+			}
+			if err != nil || string(got) != string(want) {
+				t.Errorf("Expected %s, got %s, %v", want, got, err)
+			}
+		}
+	}
+}
+
+func TestMarshalSlice(t *testing.T) {
+	when := time.Date(2023, 6, 15, 10, 20, 30, 0, time.UTC)
+	var lazy LazyTime
+	json.Unmarshal([]byte(`"2023-06-15T10:20:30Z"`), &lazy)
+	rows := []sliceRow{
+		{
+			ID: *NewNullInt64(1), Name: *NewNullString("<b>Chair</b> é"), Price: *NewNullFloat64(9.5),
+			Active: *NewNullBool(true), Created: *NewCustomTime(when), Deleted: NewCustomTime(when),
+			Title: LocalizedText{"en": "Chair"}, Counts: IntDictionary{"a": 1}, Lazy: lazy, Plain: 3, hidden: "x",
+			Small: 7, Ratio: 1e-9, Text: "a&b", Tags: []string{"x"}, Ptr: new(int), When: when, Next: &sliceRow{Plain: 1},
+		},
+		{},
+	}
+	tests := []interface{}{
+		rows,
+		[]*sliceRow{&rows[0], nil},
+		[2]sliceRow{rows[0], rows[1]},
+		[]sliceEmbedded{{sliceRow: rows[0]}},
+		[]NullString{*NewNullString("a"), {}},
+		[]*NullInt64{NewNullInt64(1), nil},
+		[]int{1, 2},
+		[]sliceRow{},
+		[]sliceRow(nil),
+	}
+	for _, items := range tests {
+		got, err := MarshalSlice([]byte("x"), items)
+		if err != nil {
+			t.Fatalf("Unexpected error for %T: %v", items, err)
+		}
+		want, _ := json.Marshal(items)
+		if string(got) != "x"+string(want) {
+			t.Errorf("Expected x%s, got %s", want, got)
+		}
+	}
+	if _, err := MarshalSlice(nil, 5); err == nil {
+		t.Errorf("Expected an error for a non-slice")
+	}
+}
+
+func benchmarkSliceRows() []sliceRow {
+	rows := make([]sliceRow, 1000)
+	for i := range rows {
+		rows[i] = sliceRow{
+			ID: *NewNullInt64(int64(i)), Name: *NewNullString("Product"), Price: *NewNullFloat64(19.99),
+			Active: *NewNullBool(i%2 == 0), Created: *NewCustomTime(time.Unix(int64(i), 0).UTC()),
+		}
+	}
+	return rows
+}
+
+func BenchmarkMarshalSlice(b *testing.B) {
+	rows := benchmarkSliceRows()
+	b.Run("MarshalSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf, _ = MarshalSlice(buf[:0], rows)
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			json.Marshal(rows)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+// normalize.go
+package octypes
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Normalize applies the null policy declared with `ocnull` tags to the
+// struct v points to, typically just before persisting it, so the
+// empty-versus-null decision of each field lives next to its declaration:
+//
+//	type Profile struct {
+//		Bio      NullString    `db:"bio" ocnull:"emptyisnull"`
+//		Nickname NullString    `db:"nickname" ocnull:"nullisempty"`
+//		Title    LocalizedText `db:"title" ocnull:"emptyisnull"`
+//	}
+//
+// With "emptyisnull", a valid zero value, such as "", 0, false, the zero
+// time or an empty map or array, becomes null. With "nullisempty", null
+// becomes the valid zero value. Untagged fields are left alone, and nested
+// structs, pointers to structs and slices of structs are walked
+// recursively. Args, NamedArgs and ScanRow apply the same tags to `db`
+// fields, so the policy holds without calling Normalize.
+func Normalize(v interface{}) error {
+	rv, err := structPointerValue(v)
+	if err != nil {
+		return err
+	}
+	return normalizeStruct(rv)
+}
+
+// normalizeStruct applies the `ocnull` tags of the addressable struct rv.
+func normalizeStruct(rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		if tag := f.Tag.Get("ocnull"); tag != "" {
+			if err := normalizeField(fv, tag); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			continue
+		}
+		if err := normalizeNested(fv); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// normalizeNested walks untagged struct, pointer and slice fields.
+func normalizeNested(fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.Struct:
+		if isScanner(fv.Type()) || fv.Type() == reflect.TypeOf(time.Time{}) {
+			return nil
+		}
+		return normalizeStruct(fv)
+	case reflect.Pointer:
+		if fv.IsNil() || fv.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		return normalizeNested(fv.Elem())
+	case reflect.Slice:
+		if k := fv.Type().Elem().Kind(); k != reflect.Struct && k != reflect.Pointer {
+			return nil
+		}
+		for i := 0; i < fv.Len(); i++ {
+			if err := normalizeNested(fv.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeField applies the policy tag to the octypes value fv.
+func normalizeField(fv reflect.Value, tag string) error {
+	var emptyIsNull bool
+	switch strings.TrimSpace(tag) {
+	case "emptyisnull":
+		emptyIsNull = true
+	case "nullisempty":
+	default:
+		return fmt.Errorf("unknown ocnull policy %q", tag)
+	}
+	p := fv.Addr().Interface()
+	n, ok := p.(interface {
+		Nullable
+		Reset()
+	})
+	if !ok {
+		return fmt.Errorf("ocnull policy on unsupported type %s", fv.Type())
+	}
+	if emptyIsNull {
+		if !n.IsNull() && isEmptyValue(p) {
+			n.Reset()
+		}
+		return nil
+	}
+	if n.IsNull() {
+		return setEmptyValue(p)
+	}
+	return nil
+}
+
+// emptier is implemented by the generic octypes types, such as Composite,
+// whose zero value a type switch cannot name.
+type emptier interface {
+	isEmpty() bool
+	setEmpty()
+}
+
+// isEmptyValue reports whether the valid value p points to is a zero value.
+func isEmptyValue(p interface{}) bool {
+	switch p := p.(type) {
+	case emptier:
+		return p.isEmpty()
+	case *NullString:
+		return p.String == ""
+	case *NullInt64:
+		return p.Int64 == 0
+	case *NullBool:
+		return !p.Bool
+	case *NullFloat64:
+		return p.Float64 == 0
+	case *CustomTime:
+		return p.Time.IsZero()
+	case *NullBytes:
+		return len(p.Bytes) == 0
+	}
+	rv := reflect.ValueOf(p).Elem()
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// setEmptyValue sets the null value p points to to the valid zero value.
+func setEmptyValue(p interface{}) error {
+	switch p := p.(type) {
+	case emptier:
+		p.setEmpty()
+	case *NullString:
+		p.NullString = sql.NullString{Valid: true}
+	case *NullInt64:
+		p.NullInt64 = sql.NullInt64{Valid: true}
+	case *NullBool:
+		p.NullBool = sql.NullBool{Valid: true}
+	case *NullFloat64:
+		p.NullFloat64 = sql.NullFloat64{Valid: true}
+	case *CustomTime:
+		p.NullTime = sql.NullTime{Valid: true}
+	case *NullBytes:
+		p.Bytes, p.Valid = []byte{}, true
+	default:
+		rv := reflect.ValueOf(p).Elem()
+		switch rv.Kind() {
+		case reflect.Map:
+			rv.Set(reflect.MakeMap(rv.Type()))
+		case reflect.Slice:
+			rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
+		default:
+			return fmt.Errorf("ocnull:\"nullisempty\" on unsupported type %s", rv.Type())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// normalize_test.go
+package octypes
+
+import (
+	"testing"
+	"time"
+)
+
+type normalizeAddress struct {
+	Line2 NullString `ocnull:"emptyisnull"`
+}
+
+type normalizeProfile struct {
+	Bio      NullString         `ocnull:"emptyisnull"`
+	Nickname NullString         `ocnull:"nullisempty"`
+	Age      NullInt64          `ocnull:"emptyisnull"`
+	Born     CustomTime         `ocnull:"emptyisnull"`
+	Title    LocalizedText      `ocnull:"emptyisnull"`
+	Tags     NullStringArray    `ocnull:"nullisempty"`
+	Point    Composite[int]     `ocnull:"emptyisnull"`
+	Size     Composite[int]     `ocnull:"nullisempty"`
+	Untagged NullString         `db:"untagged"`
+	Address  normalizeAddress   `db:"-"`
+	Previous []normalizeAddress `db:"-"`
+	Other    *normalizeAddress  `db:"-"`
+}
+
+func TestNormalize(t *testing.T) {
+	empty := ""
+	p := normalizeProfile{
+		Bio:      *NewNullStringFromPtr(&empty),
+		Age:      *NewNullInt64(0),
+		Born:     *NewCustomTime(time.Time{}),
+		Title:    LocalizedText{},
+		Point:    *NewComposite(0),
+		Untagged: *NewNullStringFromPtr(&empty),
+		Address:  normalizeAddress{Line2: *NewNullStringFromPtr(&empty)},
+		Previous: []normalizeAddress{{Line2: *NewNullStringFromPtr(&empty)}},
+		Other:    &normalizeAddress{Line2: *NewNullStringFromPtr(&empty)},
+	}
+	if err := Normalize(&p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Bio.Valid || p.Age.Valid || p.Born.Valid || p.Title != nil || p.Point.Valid {
+		t.Errorf("Expected empty values to become null, got %+v", p)
+	}
+	if !p.Nickname.Valid || p.Tags == nil || !p.Size.Valid {
+		t.Errorf("Expected null values to become empty, got %+v", p)
+	}
+	if !p.Untagged.Valid {
+		t.Errorf("Expected untagged field to be unchanged")
+	}
+	if p.Address.Line2.Valid || p.Previous[0].Line2.Valid || p.Other.Line2.Valid {
+		t.Errorf("Expected nested structs to be normalized, got %+v", p)
+	}
+
+	kept := normalizeProfile{Bio: *NewNullString("hi"), Age: *NewNullInt64(3)}
+	if err := Normalize(&kept); err != nil || !kept.Bio.Valid || !kept.Age.Valid {
+		t.Errorf("Expected non-empty values to be kept, got %+v and %v", kept, err)
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	var bad struct {
+		S NullString `ocnull:"sometimes"`
+	}
+	if err := Normalize(&bad); err == nil {
+		t.Errorf("Expected error for unknown policy")
+	}
+	var unsupported struct {
+		S string `ocnull:"emptyisnull"`
+	}
+	if err := Normalize(&unsupported); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+	if err := Normalize(normalizeProfile{}); err == nil {
+		t.Errorf("Expected error for non-pointer")
+	}
+}
+
+// normalizeMoney is a nullable struct without the V and Valid fields of
+// Composite.
+type normalizeMoney struct {
+	Cents    int64
+	Currency string
+	Set      bool
+}
+
+func (m normalizeMoney) IsNull() bool  { return !m.Set }
+func (m normalizeMoney) IsValid() bool { return m.Set }
+func (m *normalizeMoney) Reset()       { *m = normalizeMoney{} }
+
+func TestNormalizeOtherNullableStruct(t *testing.T) {
+	var s struct {
+		Price normalizeMoney `ocnull:"emptyisnull"`
+	}
+	s.Price.Set = true
+	if err := Normalize(&s); err != nil || !s.Price.Set {
+		t.Errorf("Expected the value to be kept, got %+v and %v", s, err)
+	}
+
+	var n struct {
+		Price normalizeMoney `ocnull:"nullisempty"`
+	}
+	if err := Normalize(&n); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+}
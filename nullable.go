@@ -0,0 +1,209 @@
+// nullable.go
+package octypes
+
+// Nullable is implemented by every octypes type, so generic code can check
+// heterogeneous fields for null, e.g. to find missing required fields.
+type Nullable interface {
+	// IsNull reports whether the value is SQL NULL.
+	IsNull() bool
+}
+
+var (
+	_ Nullable = NullString{}
+	_ Nullable = NullInt64{}
+	_ Nullable = NullBool{}
+	_ Nullable = NullFloat64{}
+	_ Nullable = CustomTime{}
+	_ Nullable = NullBytes{}
+	_ Nullable = LocalizedText(nil)
+	_ Nullable = LocalizedTextHstore(nil)
+	_ Nullable = IntDictionary(nil)
+	_ Nullable = NullStringArray(nil)
+	_ Nullable = NullInt64Array(nil)
+	_ Nullable = PluralizedText(nil)
+	_ Nullable = LocalizedContent(nil)
+	_ Nullable = Composite[struct{}]{}
+	_ Nullable = LazyTime{}
+)
+
+// AnyNull reports whether any of values is null. A nil interface counts
+// as null.
+func AnyNull(values ...Nullable) bool {
+	for _, v := range values {
+		if v == nil || v.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// Coalesce returns the first of vals that is not null, like SQL COALESCE,
+// or the zero value, which is null, if there is none. It layers values,
+// e.g. Coalesce(override.Title, defaults.Title).
+func Coalesce[T Nullable](vals ...T) T {
+	for _, v := range vals {
+		if !v.IsNull() {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// IsNull reports whether ns is null.
+func (ns NullString) IsNull() bool {
+	return !ns.Valid
+}
+
+// IsValid reports whether ns holds a value.
+func (ns NullString) IsValid() bool {
+	return ns.Valid
+}
+
+// IsNull reports whether ni is null.
+func (ni NullInt64) IsNull() bool {
+	return !ni.Valid
+}
+
+// IsValid reports whether ni holds a value.
+func (ni NullInt64) IsValid() bool {
+	return ni.Valid
+}
+
+// IsNull reports whether nb is null.
+func (nb NullBool) IsNull() bool {
+	return !nb.Valid
+}
+
+// IsValid reports whether nb holds a value.
+func (nb NullBool) IsValid() bool {
+	return nb.Valid
+}
+
+// IsNull reports whether nf is null.
+func (nf NullFloat64) IsNull() bool {
+	return !nf.Valid
+}
+
+// IsValid reports whether nf holds a value.
+func (nf NullFloat64) IsValid() bool {
+	return nf.Valid
+}
+
+// IsNull reports whether ct is null.
+func (ct CustomTime) IsNull() bool {
+	return !ct.Valid
+}
+
+// IsValid reports whether ct holds a value.
+func (ct CustomTime) IsValid() bool {
+	return ct.Valid
+}
+
+// IsNull reports whether nb is null.
+func (nb NullBytes) IsNull() bool {
+	return !nb.Valid
+}
+
+// IsValid reports whether nb holds a value.
+func (nb NullBytes) IsValid() bool {
+	return nb.Valid
+}
+
+// IsNull reports whether c is null.
+func (c Composite[T]) IsNull() bool {
+	return !c.Valid
+}
+
+// IsValid reports whether c holds a value.
+func (c Composite[T]) IsValid() bool {
+	return c.Valid
+}
+
+// IsNull reports whether lt is null. It does not parse: a token not
+// parsed yet is null only if it is the empty string, and other tokens that
+// fail to parse are only reported by Get.
+func (lt LazyTime) IsNull() bool {
+	if lt.raw != nil {
+		return string(lt.raw) == `""`
+	}
+	return !lt.ct.Valid
+}
+
+// IsValid reports whether lt holds a valid time. A token not parsed yet
+// is parsed on every call without keeping the result; call Get first to
+// parse it once.
+func (lt LazyTime) IsValid() bool {
+	ct, _ := lt.Get()
+	return ct.Valid
+}
+
+// IsNull reports whether lt is nil, which Value stores as NULL.
+func (lt LocalizedText) IsNull() bool {
+	return lt == nil
+}
+
+// IsValid reports whether lt is not nil. An empty map is valid.
+func (lt LocalizedText) IsValid() bool {
+	return lt != nil
+}
+
+// IsNull reports whether lh is nil, which Value stores as NULL.
+func (lh LocalizedTextHstore) IsNull() bool {
+	return lh == nil
+}
+
+// IsValid reports whether lh is not nil. An empty map is valid.
+func (lh LocalizedTextHstore) IsValid() bool {
+	return lh != nil
+}
+
+// IsNull reports whether id is nil, which Value stores as NULL.
+func (id IntDictionary) IsNull() bool {
+	return id == nil
+}
+
+// IsValid reports whether id is not nil. An empty map is valid.
+func (id IntDictionary) IsValid() bool {
+	return id != nil
+}
+
+// IsNull reports whether a is nil, which Value stores as NULL.
+func (a NullStringArray) IsNull() bool {
+	return a == nil
+}
+
+// IsValid reports whether a is not nil. An empty array is valid.
+func (a NullStringArray) IsValid() bool {
+	return a != nil
+}
+
+// IsNull reports whether a is nil, which Value stores as NULL.
+func (a NullInt64Array) IsNull() bool {
+	return a == nil
+}
+
+// IsValid reports whether a is not nil. An empty array is valid.
+func (a NullInt64Array) IsValid() bool {
+	return a != nil
+}
+
+// IsNull reports whether pt is nil, which Value stores as NULL.
+func (pt PluralizedText) IsNull() bool {
+	return pt == nil
+}
+
+// IsValid reports whether pt is not nil. An empty map is valid.
+func (pt PluralizedText) IsValid() bool {
+	return pt != nil
+}
+
+// IsNull reports whether lc is nil, which Value stores as NULL.
+func (lc LocalizedContent) IsNull() bool {
+	return lc == nil
+}
+
+// IsValid reports whether lc is not nil. An empty map is valid.
+func (lc LocalizedContent) IsValid() bool {
+	return lc != nil
+}
@@ -0,0 +1,58 @@
+// nullable_test.go
+package octypes
+
+import "testing"
+
+func TestNullable(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Nullable
+		null bool
+	}{
+		{"NullString", NullString{}, true},
+		{"NullString valid", *NewNullString("a"), false},
+		{"NullInt64", *NewNullInt64(0), false},
+		{"NullBool", NullBool{}, true},
+		{"NullFloat64", *NewNullFloat64(0), false},
+		{"CustomTime", *NewCustomTimeNull(), true},
+		{"NullBytes", *NewNullBytes([]byte{}), false},
+		{"LocalizedText nil", LocalizedText(nil), true},
+		{"LocalizedText empty", LocalizedText{}, false},
+		{"IntDictionary", IntDictionary(nil), true},
+		{"NullStringArray", NullStringArray{}, false},
+		{"Composite", Composite[int]{}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.v.IsNull(); got != tt.null {
+			t.Errorf("%s: Expected IsNull %v, got %v", tt.name, tt.null, got)
+		}
+		if v, ok := tt.v.(interface{ IsValid() bool }); !ok || v.IsValid() == tt.null {
+			t.Errorf("%s: Expected IsValid to be the opposite of IsNull", tt.name)
+		}
+	}
+
+	if AnyNull(*NewNullString("a"), *NewNullInt64(1)) {
+		t.Errorf("Expected no null values")
+	}
+	if !AnyNull(*NewNullString("a"), NullInt64{}) {
+		t.Errorf("Expected a null value")
+	}
+	if !AnyNull(nil) {
+		t.Errorf("Expected a nil interface to count as null")
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	if got := Coalesce(NullString{}, *NewNullString("user"), *NewNullString("default")); got.String != "user" {
+		t.Errorf("Expected user, got %v", got)
+	}
+	if got := Coalesce(NullInt64{}, NullInt64{}); got.Valid {
+		t.Errorf("Expected null, got %v", got)
+	}
+	if got := Coalesce[NullBool](); got.Valid {
+		t.Errorf("Expected null, got %v", got)
+	}
+	if got := Coalesce(LocalizedText(nil), LocalizedText{}); got == nil {
+		t.Errorf("Expected empty LocalizedText, got nil")
+	}
+}
@@ -0,0 +1,160 @@
+// nullbytes.go
+package octypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BytesEncoding selects the text representation of NullBytes.
+type BytesEncoding uint8
+
+const (
+	// BytesEncodingDefault uses the package default set by SetBytesEncoding.
+	BytesEncodingDefault BytesEncoding = iota
+	// BytesEncodingBase64 uses standard padded base64 (RFC 4648 section 4).
+	BytesEncodingBase64
+	// BytesEncodingBase64URL uses unpadded URL-safe base64 (RFC 4648 section 5).
+	BytesEncodingBase64URL
+	// BytesEncodingHex uses lowercase hexadecimal.
+	BytesEncodingHex
+)
+
+// String returns the name of the encoding.
+func (e BytesEncoding) String() string {
+	switch e {
+	case BytesEncodingDefault:
+		return "default"
+	case BytesEncodingBase64:
+		return "base64"
+	case BytesEncodingBase64URL:
+		return "base64url"
+	case BytesEncodingHex:
+		return "hex"
+	}
+	return fmt.Sprintf("BytesEncoding(%d)", uint8(e))
+}
+
+// NullBytes represents a []byte that may be null, encoded as text for JSON.
+type NullBytes struct {
+	Bytes []byte
+	Valid bool
+	// Encoding selects the text representation; the zero value uses the
+	// package default.
+	Encoding BytesEncoding
+}
+
+// NewNullBytes creates a new NullBytes. A nil slice is null.
+func NewNullBytes(b []byte) *NullBytes {
+	return &NullBytes{Bytes: b, Valid: b != nil}
+}
+
+// NewNullBytesWithEncoding creates a new NullBytes using the given encoding.
+func NewNullBytesWithEncoding(b []byte, enc BytesEncoding) *NullBytes {
+	return &NullBytes{Bytes: b, Valid: b != nil, Encoding: enc}
+}
+
+func (nb NullBytes) encoding() BytesEncoding {
+	if nb.Encoding == BytesEncodingDefault {
+		return defaultBytesEncoding
+	}
+	return nb.Encoding
+}
+
+// Scan implements the sql.Scanner interface.
+func (nb *NullBytes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		nb.Bytes, nb.Valid = nil, false
+	case []byte:
+		// The driver may reuse the buffer, so keep a copy.
+		nb.Bytes = append([]byte{}, v...)
+		nb.Valid = true
+	case string:
+		nb.Bytes = []byte(v)
+		nb.Valid = true
+	default:
+		return fmt.Errorf("cannot scan %T into NullBytes", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nb NullBytes) Value() (driver.Value, error) {
+	if !nb.Valid {
+		return nil, nil
+	}
+	return nb.Bytes, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nb NullBytes) MarshalText() ([]byte, error) {
+	if !nb.Valid {
+		return []byte{}, nil
+	}
+	switch enc := nb.encoding(); enc {
+	case BytesEncodingBase64:
+		return base64.StdEncoding.AppendEncode(nil, nb.Bytes), nil
+	case BytesEncodingBase64URL:
+		return base64.RawURLEncoding.AppendEncode(nil, nb.Bytes), nil
+	case BytesEncodingHex:
+		return hex.AppendEncode(nil, nb.Bytes), nil
+	default:
+		return nil, fmt.Errorf("unknown bytes encoding %v", enc)
+	}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nb *NullBytes) UnmarshalText(text []byte) error {
+	var (
+		b   []byte
+		err error
+	)
+	switch enc := nb.encoding(); enc {
+	case BytesEncodingBase64:
+		b, err = base64.StdEncoding.AppendDecode([]byte{}, text)
+	case BytesEncodingBase64URL:
+		// Accept padded input as well; some producers keep the padding.
+		b, err = base64.RawURLEncoding.AppendDecode([]byte{}, bytes.TrimRight(text, "="))
+	case BytesEncodingHex:
+		b, err = hex.AppendDecode([]byte{}, text)
+	default:
+		return fmt.Errorf("unknown bytes encoding %v", enc)
+	}
+	if err != nil {
+		return err
+	}
+	nb.Bytes = b
+	nb.Valid = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (nb NullBytes) MarshalJSON() ([]byte, error) {
+	if !nb.Valid {
+		return json.Marshal(nil)
+	}
+	text, err := nb.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (nb *NullBytes) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.New("invalid bytes format")
+	}
+	if s == nil {
+		nb.Bytes, nb.Valid = nil, false
+		return nil
+	}
+	return nb.UnmarshalText([]byte(*s))
+}
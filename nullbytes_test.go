@@ -0,0 +1,139 @@
+// nullbytes_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullBytes(t *testing.T) {
+	nb := NewNullBytes([]byte("hello"))
+	if !nb.Valid || string(nb.Bytes) != "hello" {
+		t.Errorf("Expected Valid true and Bytes 'hello', got Valid %v and Bytes '%s'", nb.Valid, nb.Bytes)
+	}
+
+	// Test JSON marshalling with the default encoding
+	jsonData, err := json.Marshal(nb)
+	if err != nil {
+		t.Errorf("Error marshalling NullBytes: %v", err)
+	}
+	if string(jsonData) != `"aGVsbG8="` {
+		t.Errorf("Expected JSON '\"aGVsbG8=\"', got %s", jsonData)
+	}
+
+	// Test JSON unmarshalling
+	nb = &NullBytes{}
+	err = json.Unmarshal([]byte(`"d29ybGQ="`), nb)
+	if err != nil {
+		t.Errorf("Error unmarshalling NullBytes: %v", err)
+	}
+	if !nb.Valid || string(nb.Bytes) != "world" {
+		t.Errorf("Expected Valid true and Bytes 'world', got Valid %v and Bytes '%s'", nb.Valid, nb.Bytes)
+	}
+
+	// Test Scan copies the driver buffer
+	buf := []byte("scan")
+	err = nb.Scan(buf)
+	if err != nil {
+		t.Errorf("Error scanning NullBytes: %v", err)
+	}
+	buf[0] = 'X'
+	if !nb.Valid || string(nb.Bytes) != "scan" {
+		t.Errorf("Expected Valid true and Bytes 'scan', got Valid %v and Bytes '%s'", nb.Valid, nb.Bytes)
+	}
+
+	// Test Value
+	val, err := nb.Value()
+	if err != nil {
+		t.Errorf("Error getting Value from NullBytes: %v", err)
+	}
+	if string(val.([]byte)) != "scan" {
+		t.Errorf("Expected Value 'scan', got '%v'", val)
+	}
+}
+
+func TestNullBytesEncodings(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01}
+	tests := []struct {
+		enc  BytesEncoding
+		want string
+	}{
+		{BytesEncodingBase64, `"+/8B"`},
+		{BytesEncodingBase64URL, `"-_8B"`},
+		{BytesEncodingHex, `"fbff01"`},
+	}
+	for _, tt := range tests {
+		jsonData, err := json.Marshal(NewNullBytesWithEncoding(data, tt.enc))
+		if err != nil {
+			t.Errorf("Error marshalling NullBytes with %v: %v", tt.enc, err)
+		}
+		if string(jsonData) != tt.want {
+			t.Errorf("Expected JSON %s for %v, got %s", tt.want, tt.enc, jsonData)
+		}
+
+		nb := &NullBytes{Encoding: tt.enc}
+		if err := json.Unmarshal(jsonData, nb); err != nil {
+			t.Errorf("Error unmarshalling NullBytes with %v: %v", tt.enc, err)
+		}
+		if !nb.Valid || string(nb.Bytes) != string(data) {
+			t.Errorf("Expected Bytes %x for %v, got %x", data, tt.enc, nb.Bytes)
+		}
+	}
+}
+
+func TestNullBytesBase64URLPadded(t *testing.T) {
+	nb := &NullBytes{Encoding: BytesEncodingBase64URL}
+	if err := nb.UnmarshalText([]byte("aGk=")); err != nil {
+		t.Errorf("Error unmarshalling padded base64url: %v", err)
+	}
+	if string(nb.Bytes) != "hi" {
+		t.Errorf("Expected Bytes 'hi', got '%s'", nb.Bytes)
+	}
+}
+
+func TestSetBytesEncoding(t *testing.T) {
+	SetBytesEncoding(BytesEncodingHex)
+	defer SetBytesEncoding(BytesEncodingDefault)
+
+	jsonData, err := json.Marshal(NewNullBytes([]byte("hi")))
+	if err != nil {
+		t.Errorf("Error marshalling NullBytes: %v", err)
+	}
+	if string(jsonData) != `"6869"` {
+		t.Errorf("Expected JSON '\"6869\"', got %s", jsonData)
+	}
+}
+
+func TestNullBytesNull(t *testing.T) {
+	nb := NewNullBytes(nil)
+	jsonData, err := json.Marshal(nb)
+	if err != nil {
+		t.Errorf("Error marshalling NullBytes: %v", err)
+	}
+	if string(jsonData) != "null" {
+		t.Errorf("Expected JSON 'null', got %s", jsonData)
+	}
+
+	val, err := nb.Value()
+	if err != nil || val != nil {
+		t.Errorf("Expected nil Value and no error, got %v and %v", val, err)
+	}
+
+	nb = NewNullBytes([]byte("x"))
+	if err := json.Unmarshal([]byte(`null`), nb); err != nil {
+		t.Errorf("Error unmarshalling NullBytes: %v", err)
+	}
+	if nb.Valid || nb.Bytes != nil {
+		t.Errorf("Expected null NullBytes, got Valid %v and Bytes %v", nb.Valid, nb.Bytes)
+	}
+}
+
+func TestNullBytesUnmarshalInvalidFormat(t *testing.T) {
+	nb := &NullBytes{Encoding: BytesEncodingHex}
+	if err := json.Unmarshal([]byte(`"zz"`), nb); err == nil {
+		t.Errorf("Expected error when unmarshalling invalid hex, got nil")
+	}
+	if err := json.Unmarshal([]byte(`42`), nb); err == nil {
+		t.Errorf("Expected error when unmarshalling a number, got nil")
+	}
+}
@@ -0,0 +1,132 @@
+// nullstats.go
+package octypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ColumnStats counts the null and non-null values scanned for a column.
+type ColumnStats struct {
+	Null  int64 `json:"null"`
+	Valid int64 `json:"valid"`
+}
+
+// Total returns the number of scanned values.
+func (cs ColumnStats) Total() int64 {
+	return cs.Null + cs.Valid
+}
+
+// NullRatio returns the fraction of scanned values that were null, or 0
+// when nothing was scanned.
+func (cs ColumnStats) NullRatio() float64 {
+	if cs.Total() == 0 {
+		return 0
+	}
+	return float64(cs.Null) / float64(cs.Total())
+}
+
+type columnCounters struct {
+	null  atomic.Int64
+	valid atomic.Int64
+}
+
+// NullStats collects per-column null statistics from scans. It is safe for
+// concurrent use; the zero value is ready to use.
+type NullStats struct {
+	columns sync.Map // map[string]*columnCounters
+}
+
+// Wrap returns a sql.Scanner that records whether each value scanned into
+// dst for column is null and then delegates to dst.
+func (s *NullStats) Wrap(column string, dst sql.Scanner) sql.Scanner {
+	return &statsScanner{counters: s.counters(column), dst: dst}
+}
+
+func (s *NullStats) counters(column string) *columnCounters {
+	if c, ok := s.columns.Load(column); ok {
+		return c.(*columnCounters)
+	}
+	c, _ := s.columns.LoadOrStore(column, &columnCounters{})
+	return c.(*columnCounters)
+}
+
+// Column returns the statistics recorded for column.
+func (s *NullStats) Column(column string) ColumnStats {
+	c, ok := s.columns.Load(column)
+	if !ok {
+		return ColumnStats{}
+	}
+	return c.(*columnCounters).stats()
+}
+
+// Snapshot returns the statistics of every column seen so far.
+func (s *NullStats) Snapshot() map[string]ColumnStats {
+	snapshot := make(map[string]ColumnStats)
+	s.columns.Range(func(k, v interface{}) bool {
+		snapshot[k.(string)] = v.(*columnCounters).stats()
+		return true
+	})
+	return snapshot
+}
+
+// AlwaysNull returns, in sorted order, the columns that were scanned at
+// least minScans times and were null every time.
+func (s *NullStats) AlwaysNull(minScans int64) []string {
+	var columns []string
+	for column, cs := range s.Snapshot() {
+		if cs.Valid == 0 && cs.Null >= minScans && cs.Null > 0 {
+			columns = append(columns, column)
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Reset discards all recorded statistics.
+func (s *NullStats) Reset() {
+	s.columns.Range(func(k, _ interface{}) bool {
+		s.columns.Delete(k)
+		return true
+	})
+}
+
+func (c *columnCounters) stats() ColumnStats {
+	return ColumnStats{Null: c.null.Load(), Valid: c.valid.Load()}
+}
+
+type statsScanner struct {
+	counters *columnCounters
+	dst      sql.Scanner
+}
+
+// Scan implements the sql.Scanner interface.
+func (ss *statsScanner) Scan(value interface{}) error {
+	if err := ss.dst.Scan(value); err != nil {
+		return err
+	}
+	if isNullScan(value, ss.dst) {
+		ss.counters.null.Add(1)
+	} else {
+		ss.counters.valid.Add(1)
+	}
+	return nil
+}
+
+// isNullScan reports whether a scan produced a null value. Besides a nil
+// source, scanners that map sources to null (such as MySQL zero dates) are
+// detected through their resulting value.
+func isNullScan(value interface{}, dst sql.Scanner) bool {
+	if value == nil {
+		return true
+	}
+	if v, ok := dst.(driver.Valuer); ok {
+		if dv, err := v.Value(); err == nil && dv == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// nullstats_test.go
+package octypes
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNullStats(t *testing.T) {
+	var stats NullStats
+	var name NullString
+	var age NullInt64
+
+	for _, v := range []interface{}{"a", nil, "b"} {
+		if err := stats.Wrap("name", &name).Scan(v); err != nil {
+			t.Errorf("Error scanning name: %v", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if err := stats.Wrap("age", &age).Scan(nil); err != nil {
+			t.Errorf("Error scanning age: %v", err)
+		}
+	}
+
+	if cs := stats.Column("name"); cs.Null != 1 || cs.Valid != 2 {
+		t.Errorf("Expected 1 null and 2 valid for name, got %+v", cs)
+	}
+	if cs := stats.Column("name"); cs.Total() != 3 || cs.NullRatio() != 1.0/3 {
+		t.Errorf("Expected total 3 and ratio 1/3, got %d and %f", cs.Total(), cs.NullRatio())
+	}
+	if got := stats.AlwaysNull(3); len(got) != 1 || got[0] != "age" {
+		t.Errorf("Expected [age] always null, got %v", got)
+	}
+	if got := stats.AlwaysNull(4); len(got) != 0 {
+		t.Errorf("Expected no column with 4 scans, got %v", got)
+	}
+	if len(stats.Snapshot()) != 2 {
+		t.Errorf("Expected 2 columns in snapshot, got %v", stats.Snapshot())
+	}
+
+	stats.Reset()
+	if cs := stats.Column("name"); cs.Total() != 0 {
+		t.Errorf("Expected no stats after Reset, got %+v", cs)
+	}
+}
+
+func TestNullStatsScanError(t *testing.T) {
+	var stats NullStats
+	var n NullInt64
+	if err := stats.Wrap("n", &n).Scan("x"); err == nil {
+		t.Errorf("Expected scan error, got nil")
+	}
+	if cs := stats.Column("n"); cs.Total() != 0 {
+		t.Errorf("Expected failed scans not to be counted, got %+v", cs)
+	}
+}
+
+func TestNullStatsZeroDate(t *testing.T) {
+	SetMySQLCompat(true)
+	defer SetMySQLCompat(false)
+
+	var stats NullStats
+	var ct CustomTime
+	if err := stats.Wrap("d", &ct).Scan("0000-00-00"); err != nil {
+		t.Errorf("Error scanning zero date: %v", err)
+	}
+	if cs := stats.Column("d"); cs.Null != 1 {
+		t.Errorf("Expected zero date to count as null, got %+v", cs)
+	}
+}
+
+func TestNullStatsConcurrent(t *testing.T) {
+	var stats NullStats
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var ns NullString
+			for j := 0; j < 100; j++ {
+				_ = stats.Wrap("c", &ns).Scan(nil)
+			}
+		}()
+	}
+	wg.Wait()
+	if cs := stats.Column("c"); cs.Null != 800 {
+		t.Errorf("Expected 800 nulls, got %+v", cs)
+	}
+}
@@ -0,0 +1,109 @@
+// numeric.go
+package octypes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrPrecisionLoss is returned by NullFloat64.Scan in strict numeric mode
+// when a decimal value cannot be represented exactly as a float64.
+var ErrPrecisionLoss = errors.New("value cannot be represented exactly as float64")
+
+// ErrIntOutOfRange is returned by NullInt64.Scan and UnmarshalJSON for
+// integers outside the int64 range.
+var ErrIntOutOfRange = errors.New("integer out of int64 range")
+
+// parseIntText parses the textual integer a driver returns for integer and
+// integral numeric columns. A fractional part made only of zeros, as
+// produced by numeric casts such as SUM(int), is accepted.
+func parseIntText(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		if strings.Trim(s[i+1:], "0") != "" {
+			return 0, fmt.Errorf("cannot scan %q into NullInt64: fractional value", s)
+		}
+		s = s[:i]
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("cannot scan %q into NullInt64: %w", s, ErrIntOutOfRange)
+		}
+		return 0, fmt.Errorf("cannot scan %q into NullInt64: invalid syntax", s)
+	}
+	return i, nil
+}
+
+// errNotJSONInt is returned by parseJSONInt for input that is not a JSON
+// integer literal.
+var errNotJSONInt = errors.New("not a JSON integer")
+
+// parseJSONInt parses the JSON integer literal b in a single pass. Integers
+// outside the int64 range fail with ErrIntOutOfRange once all their digits
+// are known to be valid; anything else, such as null, fractions and
+// exponents, fails with errNotJSONInt and is left to encoding/json.
+func parseJSONInt(b []byte) (int64, error) {
+	neg := len(b) > 0 && b[0] == '-'
+	digits := b
+	if neg {
+		digits = b[1:]
+	}
+	// JSON forbids leading zeros.
+	if len(digits) == 0 || (digits[0] == '0' && len(digits) > 1) {
+		return 0, errNotJSONInt
+	}
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+	var u uint64
+	overflow := false
+	for _, c := range digits {
+		d := uint64(c - '0')
+		if d > 9 {
+			return 0, errNotJSONInt
+		}
+		// Keep scanning after an overflow: a later non-digit makes the
+		// input something other than an out-of-range integer.
+		if overflow || u > (limit-d)/10 {
+			overflow = true
+			continue
+		}
+		u = u*10 + d
+	}
+	if overflow {
+		return 0, fmt.Errorf("%w: %s", ErrIntOutOfRange, b)
+	}
+	if neg {
+		return -int64(u), nil
+	}
+	return int64(u), nil
+}
+
+// parseFloatText parses the textual number a driver returns for float and
+// numeric columns. In strict numeric mode values that do not convert
+// exactly fail with ErrPrecisionLoss.
+func parseFloatText(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("cannot scan %q into NullFloat64: value out of range", s)
+		}
+		return 0, fmt.Errorf("cannot scan %q into NullFloat64: invalid syntax", s)
+	}
+	if strictNumericScan {
+		// NaN and Infinity are not rationals and convert exactly.
+		if r, ok := new(big.Rat).SetString(s); ok {
+			if _, exact := r.Float64(); !exact {
+				return 0, fmt.Errorf("cannot scan %q into NullFloat64: %w", s, ErrPrecisionLoss)
+			}
+		}
+	}
+	return f, nil
+}
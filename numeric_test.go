@@ -0,0 +1,169 @@
+// numeric_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNullInt64ScanText(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want int64
+	}{
+		{"42", 42},
+		{[]byte("-17"), -17},
+		{" 7 ", 7},
+		{[]byte("12.000"), 12},
+		{"9223372036854775807", 9223372036854775807},
+		{"-9223372036854775808", -9223372036854775808},
+	}
+	for _, tt := range tests {
+		ni := &NullInt64{}
+		if err := ni.Scan(tt.in); err != nil {
+			t.Errorf("Error scanning %v into NullInt64: %v", tt.in, err)
+			continue
+		}
+		if !ni.Valid || ni.Int64 != tt.want {
+			t.Errorf("Expected Valid true and Int64 %d, got Valid %v and Int64 %d", tt.want, ni.Valid, ni.Int64)
+		}
+	}
+}
+
+func TestNullInt64ScanTextInvalid(t *testing.T) {
+	for _, in := range []interface{}{"9223372036854775808", []byte("-9223372036854775809"), "1.5", "", "abc"} {
+		ni := &NullInt64{}
+		if err := ni.Scan(in); err == nil {
+			t.Errorf("Expected error when scanning %v into NullInt64, got nil", in)
+		}
+	}
+}
+
+func TestNullFloat64ScanText(t *testing.T) {
+	nf := &NullFloat64{}
+	if err := nf.Scan([]byte("3.25")); err != nil {
+		t.Errorf("Error scanning NullFloat64: %v", err)
+	}
+	if !nf.Valid || nf.Float64 != 3.25 {
+		t.Errorf("Expected Valid true and Float64 3.25, got Valid %v and Float64 %f", nf.Valid, nf.Float64)
+	}
+
+	if err := nf.Scan("1e400"); err == nil {
+		t.Errorf("Expected error when scanning out of range float, got nil")
+	}
+}
+
+func TestNullFloat64ScanNumeric(t *testing.T) {
+	nf := &NullFloat64{}
+	if err := nf.Scan([]byte("12345.6789")); err != nil {
+		t.Errorf("Error scanning NUMERIC into NullFloat64: %v", err)
+	}
+	if nf.Float64 != 12345.6789 {
+		t.Errorf("Expected Float64 12345.6789, got %v", nf.Float64)
+	}
+
+	// Without strict mode the value is rounded
+	precise := []byte("0.1000000000000000000000000001")
+	if err := nf.Scan(precise); err != nil {
+		t.Errorf("Error scanning NUMERIC into NullFloat64: %v", err)
+	}
+	if nf.Float64 != 0.1 {
+		t.Errorf("Expected Float64 0.1, got %v", nf.Float64)
+	}
+}
+
+func TestStrictNumericScan(t *testing.T) {
+	SetStrictNumericScan(true)
+	defer SetStrictNumericScan(false)
+
+	nf := &NullFloat64{}
+	for _, in := range []string{"2.5", "-0.125", "1024", "NaN", "Infinity"} {
+		if err := nf.Scan([]byte(in)); err != nil {
+			t.Errorf("Expected no error when scanning exact value %s, got %v", in, err)
+		}
+	}
+	for _, in := range []string{"0.1", "12345.6789", "9007199254740993"} {
+		err := nf.Scan([]byte(in))
+		if !errors.Is(err, ErrPrecisionLoss) {
+			t.Errorf("Expected ErrPrecisionLoss when scanning %s, got %v", in, err)
+		}
+	}
+}
+
+func TestParseJSONInt(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		err  error
+	}{
+		{"0", 0, nil},
+		{"-0", 0, nil},
+		{"42", 42, nil},
+		{"-42", -42, nil},
+		{"9223372036854775806", math.MaxInt64 - 1, nil},
+		{"9223372036854775807", math.MaxInt64, nil},
+		{"-9223372036854775807", math.MinInt64 + 1, nil},
+		{"-9223372036854775808", math.MinInt64, nil},
+		{"9223372036854775808", 0, ErrIntOutOfRange},
+		{"9223372036854775810", 0, ErrIntOutOfRange},
+		{"-9223372036854775809", 0, ErrIntOutOfRange},
+		{"18446744073709551616", 0, ErrIntOutOfRange},
+		{"99999999999999999999999999", 0, ErrIntOutOfRange},
+		{"99999999999999999999.5", 0, errNotJSONInt},
+		{"", 0, errNotJSONInt},
+		{"-", 0, errNotJSONInt},
+		{"01", 0, errNotJSONInt},
+		{"1.0", 0, errNotJSONInt},
+		{"1e3", 0, errNotJSONInt},
+		{"null", 0, errNotJSONInt},
+		{`"1"`, 0, errNotJSONInt},
+	}
+	for _, tt := range tests {
+		got, err := parseJSONInt([]byte(tt.in))
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Expected %d, %v for %q, got %d, %v", tt.want, tt.err, tt.in, got, err)
+		}
+	}
+}
+
+func TestNullInt64UnmarshalJSONFastPath(t *testing.T) {
+	for _, in := range []string{"0", "-17", "9223372036854775807", "-9223372036854775808", "null"} {
+		var got NullInt64
+		if err := got.UnmarshalJSON([]byte(in)); err != nil {
+			t.Fatalf("Unexpected error for %s: %v", in, err)
+		}
+		var want *int64
+		json.Unmarshal([]byte(in), &want)
+		if (want == nil) == got.Valid || (want != nil && *want != got.Int64) {
+			t.Errorf("Expected %s, got %#v", in, got)
+		}
+	}
+	var ni NullInt64
+	if err := ni.UnmarshalJSON([]byte("1.5")); err == nil || errors.Is(err, ErrIntOutOfRange) {
+		t.Errorf("Expected a format error for a fraction, got %v", err)
+	}
+	for _, in := range []string{"9223372036854775808", "-9223372036854775809"} {
+		ni = *NewNullInt64(7)
+		err := json.Unmarshal([]byte(`{"n":`+in+`}`), &struct{ N *NullInt64 }{&ni})
+		if !errors.Is(err, ErrIntOutOfRange) {
+			t.Errorf("Expected ErrIntOutOfRange for %s, got %v", in, err)
+		}
+		if ni.Int64 != 7 {
+			t.Errorf("Expected the value to be left unchanged, got %d", ni.Int64)
+		}
+	}
+	if err := ni.Scan("9223372036854775808"); !errors.Is(err, ErrIntOutOfRange) {
+		t.Errorf("Expected ErrIntOutOfRange from Scan, got %v", err)
+	}
+}
+
+func BenchmarkNullInt64UnmarshalJSON(b *testing.B) {
+	data := []byte("1234567890123")
+	b.ReportAllocs()
+	var ni NullInt64
+	for i := 0; i < b.N; i++ {
+		ni.UnmarshalJSON(data)
+	}
+}
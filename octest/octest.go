@@ -0,0 +1,117 @@
+// Package octest provides test assertions for code using octypes values,
+// replacing the marshal, unmarshal and compare boilerplate otherwise
+// repeated in every test:
+//
+//	func TestProduct(t *testing.T) {
+//		p := loadProduct(t)
+//		octest.AssertNull(t, p.DeletedAt)
+//		octest.AssertEqualJSON(t, `{"en":"Chair","fr":"Chaise"}`, p.Name)
+//		octest.RoundTrip(t, p.Name)
+//	}
+package octest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/coffyg/octypes"
+)
+
+// AssertNull fails the test if v is not null.
+func AssertNull(t testing.TB, v octypes.Nullable) {
+	t.Helper()
+	if v != nil && !v.IsNull() {
+		t.Errorf("Expected a null value, got %#v", v)
+	}
+}
+
+// AssertNotNull fails the test if v is null.
+func AssertNotNull(t testing.TB, v octypes.Nullable) {
+	t.Helper()
+	if v == nil || v.IsNull() {
+		t.Errorf("Expected a non-null value, got %#v", v)
+	}
+}
+
+// AssertEqualJSON fails the test if got does not marshal to JSON equivalent
+// to want. Objects compare regardless of key order and whitespace.
+func AssertEqualJSON(t testing.TB, want string, got any) {
+	t.Helper()
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Errorf("Error marshalling %#v: %v", got, err)
+		return
+	}
+	var w, g any
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Errorf("Invalid expected JSON %s: %v", want, err)
+		return
+	}
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Errorf("Invalid JSON %s: %v", b, err)
+		return
+	}
+	if !reflect.DeepEqual(w, g) {
+		t.Errorf("Expected JSON %s, got %s", want, b)
+	}
+}
+
+// RoundTrip fails the test unless v survives a JSON round trip and, when T
+// supports them, binary (encoding.BinaryMarshaler) and SQL (driver.Valuer
+// and sql.Scanner) round trips. Values are compared with their Equal method
+// when T has one, and with reflect.DeepEqual otherwise.
+func RoundTrip[T any](t testing.TB, v T) {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Errorf("Error marshalling %#v to JSON: %v", v, err)
+	} else {
+		var got T
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Errorf("Error unmarshalling JSON %s: %v", b, err)
+		} else if !equal(v, got) {
+			t.Errorf("Expected %#v after JSON round trip through %s, got %#v", v, b, got)
+		}
+	}
+
+	if m, ok := any(v).(encoding.BinaryMarshaler); ok {
+		var got T
+		if u, ok := any(&got).(encoding.BinaryUnmarshaler); ok {
+			data, err := m.MarshalBinary()
+			if err != nil {
+				t.Errorf("Error marshalling %#v to binary: %v", v, err)
+			} else if err := u.UnmarshalBinary(data); err != nil {
+				t.Errorf("Error unmarshalling binary %x: %v", data, err)
+			} else if !equal(v, got) {
+				t.Errorf("Expected %#v after binary round trip, got %#v", v, got)
+			}
+		}
+	}
+
+	if valuer, ok := any(v).(driver.Valuer); ok {
+		var got T
+		if s, ok := any(&got).(sql.Scanner); ok {
+			dv, err := valuer.Value()
+			if err != nil {
+				t.Errorf("Error getting driver value of %#v: %v", v, err)
+			} else if err := s.Scan(dv); err != nil {
+				t.Errorf("Error scanning driver value %#v: %v", dv, err)
+			} else if !equal(v, got) {
+				t.Errorf("Expected %#v after SQL round trip through %#v, got %#v", v, dv, got)
+			}
+		}
+	}
+}
+
+// equal compares a and b with their Equal method if T has one.
+func equal[T any](a, b T) bool {
+	if e, ok := any(a).(interface{ Equal(T) bool }); ok {
+		return e.Equal(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,68 @@
+// octest_test.go
+package octest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/coffyg/octypes"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertNull(t *testing.T) {
+	AssertNull(t, octypes.NullString{})
+	AssertNotNull(t, *octypes.NewNullInt64(0))
+
+	r := &recorder{TB: t}
+	AssertNull(r, *octypes.NewNullString("a"))
+	AssertNotNull(r, octypes.LocalizedText(nil))
+	if len(r.failures) != 2 {
+		t.Errorf("Expected 2 failures, got %v", r.failures)
+	}
+}
+
+func TestAssertEqualJSON(t *testing.T) {
+	AssertEqualJSON(t, `{"fr": "Chaise", "en": "Chair"}`, octypes.LocalizedText{"en": "Chair", "fr": "Chaise"})
+	AssertEqualJSON(t, `null`, octypes.NullString{})
+
+	r := &recorder{TB: t}
+	AssertEqualJSON(r, `"b"`, *octypes.NewNullString("a"))
+	AssertEqualJSON(r, `{`, *octypes.NewNullString("a"))
+	if len(r.failures) != 2 {
+		t.Errorf("Expected 2 failures, got %v", r.failures)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	RoundTrip(t, *octypes.NewNullString("a"))
+	RoundTrip(t, octypes.NullInt64{})
+	RoundTrip(t, *octypes.NewNullFloat64(1.5))
+	RoundTrip(t, *octypes.NewNullBool(true))
+	RoundTrip(t, *octypes.NewCustomTime(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)))
+	RoundTrip(t, octypes.LocalizedText{"en": "a"})
+	RoundTrip(t, octypes.IntDictionary{"a": 1})
+	RoundTrip(t, octypes.NullStringArray{*octypes.NewNullString("a"), {}})
+
+	r := &recorder{TB: t}
+	RoundTrip(r, lossy{N: 1})
+	if len(r.failures) != 1 {
+		t.Errorf("Expected 1 failure, got %v", r.failures)
+	}
+}
+
+// lossy drops its value when marshalled.
+type lossy struct {
+	N int `json:"-"`
+}
@@ -2,10 +2,12 @@
 package octypes
 
 import (
+	"bytes"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"slices"
 	"strconv"
 	"time"
 )
@@ -19,18 +21,26 @@ type Pagination struct {
 }
 
 // CustomTime extends sql.NullTime to handle custom time formats.
+//
+// Constructors strip the monotonic clock reading, so two CustomTime values
+// built from the same instant compare equal with == as long as they share
+// a location. Use Time.Equal to compare instants across locations.
 type CustomTime struct {
 	sql.NullTime
 }
 
 // TimeResponse represents various time formats for JSON marshalling.
+// Localized and Relative are only set when enabled with
+// SetTimeLocalizedOutput and SetTimeRelativeOutput.
 type TimeResponse struct {
-	ISO    string `json:"iso"`
-	TZ     string `json:"tz"`
-	Unix   int64  `json:"unix"`
-	UnixMS int64  `json:"unixms"`
-	US     int64  `json:"us"`
-	Full   int64  `json:"full,omitempty,string"`
+	ISO       string `json:"iso"`
+	TZ        string `json:"tz"`
+	Unix      int64  `json:"unix"`
+	UnixMS    int64  `json:"unixms"`
+	US        int64  `json:"us"`
+	Full      int64  `json:"full,omitempty,string"`
+	Localized string `json:"localized,omitempty"`
+	Relative  string `json:"relative,omitempty"`
 }
 
 // NewCustomTimeNull creates a new CustomTime with a null value.
@@ -38,28 +48,44 @@ func NewCustomTimeNull() *CustomTime {
 	return &CustomTime{}
 }
 
-// NewCustomTime creates a new CustomTime from time.Time.
+// NewCustomTime creates a new CustomTime from time.Time, stripping any
+// monotonic clock reading.
 func NewCustomTime(t time.Time) *CustomTime {
 	return &CustomTime{
 		NullTime: sql.NullTime{
-			Time:  t,
+			Time:  t.Round(0),
 			Valid: true,
 		},
 	}
 }
 
-// NewCustomTimeInt64 creates a new CustomTime from int64 timestamp (milliseconds).
+// NewCustomTimeNow creates a new CustomTime from the current time, as
+// reported by the clock set with SetNowFunc.
+func NewCustomTimeNow() *CustomTime {
+	return NewCustomTime(nowFunc())
+}
+
+// NewCustomTimeInt64 creates a new CustomTime from int64 timestamp
+// (milliseconds, unless changed with SetUnixTimestampPolicy).
 func NewCustomTimeInt64(int64Time int64) *CustomTime {
-	return NewCustomTime(time.Unix(0, int64Time*int64(time.Millisecond)))
+	return NewCustomTime(unixIntTime(int64Time))
 }
 
-// NewCustomTimeFloat64 creates a new CustomTime from float64 timestamp (milliseconds).
+// NewCustomTimeFloat64 creates a new CustomTime from float64 timestamp
+// (milliseconds, unless changed with SetUnixTimestampPolicy).
 func NewCustomTimeFloat64(float64Time float64) *CustomTime {
-	return NewCustomTime(time.Unix(0, int64(float64Time)*int64(time.Millisecond)))
+	return NewCustomTime(unixFloatTime(float64Time))
 }
 
 // Scan implements the sql.Scanner interface.
 func (ct *CustomTime) Scan(value interface{}) error {
+	if err := ct.scan(value); err != nil {
+		return err
+	}
+	return ct.checkYearRange()
+}
+
+func (ct *CustomTime) scan(value interface{}) error {
 	if value == nil {
 		*ct = CustomTime{}
 		return nil
@@ -67,83 +93,190 @@ func (ct *CustomTime) Scan(value interface{}) error {
 
 	switch v := value.(type) {
 	case time.Time:
+		// go-sql-driver/mysql with parseTime returns zero dates as the zero time.
+		if mysqlCompat && v.IsZero() {
+			*ct = CustomTime{}
+			return nil
+		}
 		ct.Time = v
 		ct.Valid = true
 	case string:
-		t, err := time.Parse("2006-01-02", v)
-		if err != nil {
-			return err
-		}
-		ct.Time = t
-		ct.Valid = true
+		return ct.scanText(v)
+	case []byte:
+		return ct.scanText(string(v))
+	case int64:
+		return ct.scanNumber(float64(v), v, true)
+	case float64:
+		return ct.scanNumber(v, int64(v), false)
 	default:
 		return ct.NullTime.Scan(value)
 	}
 	return nil
 }
 
+func (ct *CustomTime) scanText(s string) error {
+	if mysqlCompat && isMySQLZeroDate(s) {
+		*ct = CustomTime{}
+		return nil
+	}
+	t, err := parseTimeText(s)
+	if err != nil {
+		return err
+	}
+	ct.Time = t
+	ct.Valid = true
+	return nil
+}
+
 // Value implements the driver.Valuer interface.
 func (ct CustomTime) Value() (driver.Value, error) {
 	if !ct.Valid {
 		return nil, nil
 	}
+	if timeValueLayout != "" {
+		return ct.Time.Format(timeValueLayout), nil
+	}
 	return ct.Time, nil
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 func (ct CustomTime) MarshalJSON() ([]byte, error) {
-	if !ct.Valid {
-		return json.Marshal(nil)
+	if ct.Valid && timeMarshaler != nil {
+		return ct.AppendJSON(nil)
 	}
-
-	tr := TimeResponse{
-		ISO:    ct.Time.Format(time.RFC3339Nano),
-		TZ:     ct.Time.Location().String(),
-		Unix:   ct.Time.Unix(),
-		UnixMS: ct.Time.UnixMilli(),
-		US:     int64(ct.Time.Nanosecond()),
-		Full:   ct.Time.UnixMicro(),
+	if ct.Valid && timeJSONFormat == TimeFormatObject {
+		return ct.AppendJSON(make([]byte, 0, 192))
 	}
+	return ct.AppendJSON(make([]byte, 0, len(time.RFC3339Nano)+2))
+}
 
-	return json.Marshal(tr)
+// AppendJSON appends the JSON encoding of ct to dst, as MarshalJSON
+// returns it.
+func (ct CustomTime) AppendJSON(dst []byte) ([]byte, error) {
+	if !ct.Valid {
+		return append(dst, "null"...), nil
+	}
+	t := ct.Time
+	if timeMarshalPrecision > 0 {
+		t = t.Truncate(timeMarshalPrecision)
+	}
+	if timeMarshaler != nil {
+		b, err := timeMarshaler.MarshalTime(t)
+		if dst == nil {
+			return b, err
+		}
+		return append(dst, b...), err
+	}
+
+	switch timeJSONFormat {
+	case TimeFormatRFC3339:
+		// RFC 3339 text has nothing to escape in JSON.
+		dst = append(dst, '"')
+		return append(t.AppendFormat(dst, time.RFC3339Nano), '"'), nil
+	case TimeFormatUnixMS:
+		return appendInt(dst, t.UnixMilli()), nil
+	}
+
+	if timeMarshalCache && !relativeTimeOutput {
+		return appendCachedTimeResponse(dst, ct, t), nil
+	}
+	return appendTimeResponse(dst, ct, t), nil
+}
+
+// appendTimeResponse appends the TimeResponse of t, the possibly truncated
+// time of ct, as encoding/json would marshal it. The object is written
+// directly since its schema is fixed.
+func appendTimeResponse(dst []byte, ct CustomTime, t time.Time) []byte {
+	dst = append(dst, `{"iso":"`...)
+	dst = t.AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, `","tz":`...)
+	dst = appendJSONString(dst, t.Location().String())
+	dst = append(dst, `,"unix":`...)
+	dst = appendInt(dst, t.Unix())
+	dst = append(dst, `,"unixms":`...)
+	dst = appendInt(dst, t.UnixMilli())
+	dst = append(dst, `,"us":`...)
+	dst = appendInt(dst, int64(t.Nanosecond()))
+	if full := t.UnixMicro(); full != 0 {
+		dst = append(dst, `,"full":"`...)
+		dst = appendInt(dst, full)
+		dst = append(dst, '"')
+	}
+	if localizedTimeLocale != "" {
+		if localized := ct.Format(localizedTimeLocale, localizedTimeStyle); localized != "" {
+			dst = append(dst, `,"localized":`...)
+			dst = appendJSONString(dst, localized)
+		}
+	}
+	if relativeTimeOutput {
+		if relative := ct.Humanize(); relative != "" {
+			dst = append(dst, `,"relative":`...)
+			dst = appendJSONString(dst, relative)
+		}
+	}
+	return append(dst, '}')
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (ct *CustomTime) UnmarshalJSON(b []byte) error {
+	if err := ct.unmarshalJSON(b); err != nil {
+		return err
+	}
+	return ct.checkYearRange()
+}
+
+func (ct *CustomTime) unmarshalJSON(b []byte) error {
 	// Handle null input
 	if string(b) == "null" {
 		*ct = CustomTime{}
 		return nil
 	}
 
-	var tr TimeResponse
-	if err := json.Unmarshal(b, &tr); err == nil && tr.ISO != "" {
-		t, err := time.Parse(time.RFC3339Nano, tr.ISO)
-		if err != nil {
-			return err
+	if u, ok := timeMarshaler.(TimeUnmarshaler); ok {
+		t, err := u.UnmarshalTime(b)
+		if err == nil {
+			ct.Time = t
+			ct.Valid = true
+			return nil
 		}
-		ct.Time = t
+	}
+
+	if n, err := parseJSONInt(b); err == nil {
+		ct.Time = unixIntTime(n)
 		ct.Valid = true
 		return nil
 	}
 
+	var tr timeResponseFields
+	if err := json.Unmarshal(b, &tr); err == nil {
+		t, ok, err := tr.time()
+		if err != nil {
+			return err
+		}
+		if ok {
+			ct.Time = preserveLocation(t, tr.TZ)
+			ct.Valid = true
+			return nil
+		}
+	}
+
 	var unixms int64
 	if err := json.Unmarshal(b, &unixms); err == nil {
-		ct.Time = time.Unix(0, unixms*int64(time.Millisecond))
+		ct.Time = unixIntTime(unixms)
 		ct.Valid = true
 		return nil
 	}
 
 	var floatUnixms float64
 	if err := json.Unmarshal(b, &floatUnixms); err == nil {
-		ct.Time = time.Unix(0, int64(floatUnixms)*int64(time.Millisecond))
+		ct.Time = unixFloatTime(floatUnixms)
 		ct.Valid = true
 		return nil
 	}
 
 	var ts string
 	if err := json.Unmarshal(b, &ts); err == nil {
-		t, err := time.Parse("2006-01-02", ts)
+		t, err := parseJSONTimeString(ts)
 		if err != nil {
 			return err
 		}
@@ -160,11 +293,24 @@ type NullString struct {
 	sql.NullString
 }
 
-// NewNullString creates a new NullString.
+// NewNullString creates a new NullString. An empty s gives null, unlike
+// UnmarshalJSON and Scan; NewNullStringAllowEmpty keeps it valid.
 func NewNullString(s string) *NullString {
 	return &NullString{sql.NullString{String: s, Valid: s != ""}}
 }
 
+// NewNullStringNullIfEmpty creates a NullString that is null if s is
+// empty. It is NewNullString under a name stating its policy.
+func NewNullStringNullIfEmpty(s string) *NullString {
+	return NewNullString(s)
+}
+
+// NewNullStringAllowEmpty creates a valid NullString, even if s is empty,
+// which is how UnmarshalJSON and Scan treat "".
+func NewNullStringAllowEmpty(s string) *NullString {
+	return &NullString{sql.NullString{String: s, Valid: true}}
+}
+
 // Scan implements the sql.Scanner interface.
 func (ns *NullString) Scan(value interface{}) error {
 	return ns.NullString.Scan(value)
@@ -180,14 +326,34 @@ func (ns NullString) Value() (driver.Value, error) {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (ns NullString) MarshalJSON() ([]byte, error) {
+	if len(ns.String) < 256 {
+		return ns.AppendJSON(make([]byte, 0, len(ns.String)+16))
+	}
+	// Escapes make the output size unknown: encode long strings in a pooled
+	// buffer and copy out the exact result.
+	b := defaultBufferPool.Get(len(ns.String) + len(ns.String)/8)
+	buf, _ := ns.AppendJSON((*b)[:0])
+	out := bytes.Clone(buf)
+	*b = buf
+	defaultBufferPool.Put(b)
+	return out, nil
+}
+
+// AppendJSON appends the JSON encoding of ns to dst, as MarshalJSON
+// returns it.
+func (ns NullString) AppendJSON(dst []byte) ([]byte, error) {
 	if ns.Valid {
-		return json.Marshal(ns.String)
+		return appendJSONString(dst, ns.String), nil
 	}
-	return json.Marshal(nil)
+	return append(dst, "null"...), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (ns *NullString) UnmarshalJSON(b []byte) error {
+	if s, ok := jsonPlainString(b); ok {
+		ns.String, ns.Valid = s, true
+		return nil
+	}
 	var s *string
 	if err := json.Unmarshal(b, &s); err != nil {
 		return err
@@ -210,13 +376,73 @@ func (lt *LocalizedText) Scan(value interface{}) error {
 		*lt = nil
 		return nil
 	}
-	asBytes, ok := value.([]byte)
-	if !ok {
-		return errors.New("Scan source is not []byte")
+	asBytes, err := jsonScanSource(value)
+	if err != nil {
+		return err
+	}
+	// Columns being migrated from hstore may still hold hstore text.
+	if isHstoreText(asBytes) {
+		return lt.scanHstore(string(asBytes))
+	}
+	return lt.decodeJSON(asBytes)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (lt *LocalizedText) UnmarshalJSON(b []byte) error {
+	return lt.decodeJSON(b)
+}
+
+// decodeJSON replaces lt with the JSON object b. It is shared by Scan and
+// UnmarshalJSON so both apply the same key normalization.
+func (lt *LocalizedText) decodeJSON(b []byte) error {
+	m := make(map[string]string, jsonObjectLen(b))
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	return lt.set(m)
+}
+
+// set replaces lt with m after applying the configured key normalization,
+// sanitization, length limit and interning.
+func (lt *LocalizedText) set(m map[string]string) error {
+	v, err := LocalizedText(m).normalized()
+	if err != nil {
+		return err
+	}
+	pool := textInternPool
+	if pool == nil {
+		pool = mapInternPool()
+	}
+	if pool != nil && v != nil {
+		v = internMap(pool, v)
+	}
+	*lt = v
+	return nil
+}
+
+// normalized returns lt after the configured key normalization,
+// sanitization and length limit.
+func (lt LocalizedText) normalized() (LocalizedText, error) {
+	v, err := lt.normalizeTags()
+	if err != nil {
+		return nil, err
+	}
+	if err := v.sanitize(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jsonScanSource returns the JSON document held by a Scan source. Drivers
+// hand json/jsonb columns over either as []byte or as string.
+func jsonScanSource(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
 	}
-	// Reset lt before unmarshalling
-	*lt = make(LocalizedText)
-	return json.Unmarshal(asBytes, lt)
+	return nil, errors.New("Scan source is not []byte or string")
 }
 
 // Value implements the driver.Valuer interface.
@@ -224,7 +450,7 @@ func (lt LocalizedText) Value() (driver.Value, error) {
 	if lt == nil {
 		return nil, nil
 	}
-	return json.Marshal(lt)
+	return jsonValue(lt, map[string]string(lt))
 }
 
 // NullInt64 extends sql.NullInt64 to handle JSON marshalling.
@@ -251,9 +477,25 @@ func NewNullInt64FromString(s string) *NullInt64 {
 
 // Scan implements the sql.Scanner interface.
 func (ni *NullInt64) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return ni.scanText(v)
+	case []byte:
+		return ni.scanText(string(v))
+	}
 	return ni.NullInt64.Scan(value)
 }
 
+func (ni *NullInt64) scanText(s string) error {
+	i, err := parseIntText(s)
+	if err != nil {
+		return err
+	}
+	ni.Int64 = i
+	ni.Valid = true
+	return nil
+}
+
 // Value implements the driver.Valuer interface.
 func (ni NullInt64) Value() (driver.Value, error) {
 	if ni.Valid {
@@ -264,18 +506,37 @@ func (ni NullInt64) Value() (driver.Value, error) {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (ni NullInt64) MarshalJSON() ([]byte, error) {
+	// Not written with AppendJSON, so that it stays inlinable and callers
+	// discarding or copying the result do not allocate.
 	if ni.Valid {
-		return json.Marshal(ni.Int64)
+		return appendInt(make([]byte, 0, 20), ni.Int64), nil
 	}
-	return json.Marshal(nil)
+	return []byte("null"), nil
+}
+
+// AppendJSON appends the JSON encoding of ni to dst, as MarshalJSON
+// returns it.
+func (ni NullInt64) AppendJSON(dst []byte) ([]byte, error) {
+	if ni.Valid {
+		return appendInt(dst, ni.Int64), nil
+	}
+	return append(dst, "null"...), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (ni *NullInt64) UnmarshalJSON(b []byte) error {
-	var i *int64
-	if err := json.Unmarshal(b, &i); err == nil {
-		if i != nil {
-			ni.Int64 = *i
+	i, err := parseJSONInt(b)
+	if err == nil {
+		ni.Int64, ni.Valid = i, true
+		return nil
+	}
+	if errors.Is(err, ErrIntOutOfRange) {
+		return err
+	}
+	var p *int64
+	if err := json.Unmarshal(b, &p); err == nil {
+		if p != nil {
+			ni.Int64 = *p
 			ni.Valid = true
 		} else {
 			ni.Valid = false
@@ -314,18 +575,30 @@ func (nb *NullBool) Scan(value interface{}) error {
 
 // Value implements the driver.Valuer interface.
 func (nb NullBool) Value() (driver.Value, error) {
-	if nb.Valid {
-		return nb.Bool, nil
+	if !nb.Valid {
+		return nil, nil
 	}
-	return nil, nil
+	if boolValueMode == BoolValueInt {
+		if nb.Bool {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	}
+	return nb.Bool, nil
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 func (nb NullBool) MarshalJSON() ([]byte, error) {
-	if nb.Valid {
-		return json.Marshal(nb.Bool)
+	return nb.AppendJSON(make([]byte, 0, 5))
+}
+
+// AppendJSON appends the JSON encoding of nb to dst, as MarshalJSON
+// returns it.
+func (nb NullBool) AppendJSON(dst []byte) ([]byte, error) {
+	if !nb.Valid {
+		return append(dst, "null"...), nil
 	}
-	return json.Marshal(nil)
+	return strconv.AppendBool(dst, nb.Bool), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -367,9 +640,25 @@ func NewNullFloat64FromString(s string) *NullFloat64 {
 
 // Scan implements the sql.Scanner interface.
 func (nf *NullFloat64) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return nf.scanText(v)
+	case []byte:
+		return nf.scanText(string(v))
+	}
 	return nf.NullFloat64.Scan(value)
 }
 
+func (nf *NullFloat64) scanText(s string) error {
+	f, err := parseFloatText(s)
+	if err != nil {
+		return err
+	}
+	nf.Float64 = f
+	nf.Valid = true
+	return nil
+}
+
 // Value implements the driver.Valuer interface.
 func (nf NullFloat64) Value() (driver.Value, error) {
 	if nf.Valid {
@@ -378,12 +667,22 @@ func (nf NullFloat64) Value() (driver.Value, error) {
 	return nil, nil
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. The number is
+// formatted exactly as encoding/json formats a float64: the shortest
+// digits that round-trip, so 1.10 is 1.1. It deliberately follows
+// encoding/json's switch from the 'f' to the 'e' format below 1e-6 and from
+// 1e21, rather than the thresholds of strconv's 'g' format.
 func (nf NullFloat64) MarshalJSON() ([]byte, error) {
+	return nf.AppendJSON(make([]byte, 0, 24))
+}
+
+// AppendJSON appends the JSON encoding of nf to dst, as MarshalJSON
+// returns it.
+func (nf NullFloat64) AppendJSON(dst []byte) ([]byte, error) {
 	if nf.Valid {
-		return json.Marshal(nf.Float64)
+		return appendJSONFloat(dst, nf.Float64)
 	}
-	return json.Marshal(nil)
+	return append(dst, "null"...), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -410,13 +709,34 @@ func (id *IntDictionary) Scan(value interface{}) error {
 		*id = nil
 		return nil
 	}
-	asBytes, ok := value.([]byte)
-	if !ok {
-		return errors.New("Scan source is not []byte")
+	asBytes, err := jsonScanSource(value)
+	if err != nil {
+		return err
+	}
+	return id.decodeJSON(asBytes)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (id *IntDictionary) UnmarshalJSON(b []byte) error {
+	return id.decodeJSON(b)
+}
+
+// decodeJSON replaces id with the JSON object b, interning keys when
+// interning is enabled.
+func (id *IntDictionary) decodeJSON(b []byte) error {
+	m := make(map[string]int, jsonObjectLen(b))
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	if pool := mapInternPool(); pool != nil && m != nil {
+		interned := make(map[string]int, len(m))
+		for k, v := range m {
+			interned[pool.Intern(k)] = v
+		}
+		m = interned
 	}
-	// Reset id before unmarshalling
-	*id = make(IntDictionary)
-	return json.Unmarshal(asBytes, id)
+	*id = m
+	return nil
 }
 
 // Value implements the driver.Valuer interface.
@@ -424,5 +744,34 @@ func (id IntDictionary) Value() (driver.Value, error) {
 	if id == nil {
 		return nil, nil
 	}
-	return json.Marshal(id)
+	return jsonValue(id, map[string]int(id))
+}
+
+// MarshalJSON implements the json.Marshaler interface. Keys are sorted, as
+// encoding/json does for maps.
+func (id IntDictionary) MarshalJSON() ([]byte, error) {
+	return id.AppendJSON(make([]byte, 0, 2+len(id)*16))
+}
+
+// AppendJSON appends the JSON encoding of id to dst, as MarshalJSON
+// returns it.
+func (id IntDictionary) AppendJSON(dst []byte) ([]byte, error) {
+	if id == nil {
+		return append(dst, "null"...), nil
+	}
+	keys := make([]string, 0, len(id))
+	for k := range id {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	buf := append(dst, '{')
+	for i, k := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONString(buf, k)
+		buf = append(buf, ':')
+		buf = appendInt(buf, int64(id[k]))
+	}
+	return append(buf, '}'), nil
 }
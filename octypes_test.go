@@ -3,6 +3,8 @@ package octypes
 
 import (
 	"encoding/json"
+	"math"
+	"math/rand"
 	"strconv"
 	"testing"
 	"time"
@@ -58,6 +60,18 @@ func TestNullString(t *testing.T) {
 	}
 }
 
+func TestNullStringConstructors(t *testing.T) {
+	if ns := NewNullStringNullIfEmpty(""); ns.Valid {
+		t.Errorf("Expected null, got %+v", ns)
+	}
+	if ns := NewNullStringAllowEmpty(""); !ns.Valid {
+		t.Errorf("Expected valid empty string, got %+v", ns)
+	}
+	if ns := NewNullStringNullIfEmpty("a"); !ns.Valid || ns.String != "a" {
+		t.Errorf("Expected valid a, got %+v", ns)
+	}
+}
+
 func TestNullInt64(t *testing.T) {
 	// Test constructor with int64
 	ni := NewNullInt64(42)
@@ -683,6 +697,40 @@ func TestNullFloat64ValueNil(t *testing.T) {
 	}
 }
 
+func TestNullFloat64MarshalJSONExhaustive(t *testing.T) {
+	check := func(f float64) {
+		t.Helper()
+		got, err := NewNullFloat64(f).MarshalJSON()
+		want, wantErr := json.Marshal(f)
+		if (err != nil) != (wantErr != nil) || string(got) != string(want) {
+			t.Fatalf("Expected %s for %v, got %s", want, f, got)
+		}
+	}
+	// Two-decimal values such as prices, including trailing zeros like
+	// 0.10 and 1.10, and their negatives.
+	for i := -100000; i <= 100000; i++ {
+		check(float64(i) / 100)
+		check(float64(i) / 1000)
+	}
+	// Around the integer table and the exponent thresholds.
+	for _, base := range []float64{99, 100, 999, 1000, 1e-6, 1e21} {
+		for _, f := range []float64{base, math.Nextafter(base, 0), math.Nextafter(base, math.Inf(1))} {
+			check(f)
+			check(-f)
+		}
+	}
+	for exp := -320; exp <= 308; exp++ {
+		check(math.Pow(10, float64(exp)))
+		check(-1.5 * math.Pow(10, float64(exp)))
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		if f := math.Float64frombits(r.Uint64()); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			check(f)
+		}
+	}
+}
+
 func TestCustomTimeValueNil(t *testing.T) {
 	ct := NewCustomTimeNull()
 	val, err := ct.Value()
@@ -762,3 +810,159 @@ func TestCustomTimeUnmarshalStringDate(t *testing.T) {
 		t.Errorf("Expected Valid true and Time %v, got Valid %v and Time %v", expectedTime, ct.Valid, ct.Time)
 	}
 }
+
+func TestMapTypesScanString(t *testing.T) {
+	lt := &LocalizedText{}
+	err := lt.Scan(`{"en":"Hi"}`)
+	if err != nil {
+		t.Errorf("Error scanning string into LocalizedText: %v", err)
+	}
+	if (*lt)["en"] != "Hi" {
+		t.Errorf("Expected 'Hi', got '%s'", (*lt)["en"])
+	}
+
+	id := &IntDictionary{}
+	err = id.Scan(`{"one":1}`)
+	if err != nil {
+		t.Errorf("Error scanning string into IntDictionary: %v", err)
+	}
+	if (*id)["one"] != 1 {
+		t.Errorf("Expected 1, got %d", (*id)["one"])
+	}
+
+	// nil resets both types
+	if err := lt.Scan(nil); err != nil || *lt != nil {
+		t.Errorf("Expected nil LocalizedText and no error, got %v and %v", *lt, err)
+	}
+	if err := id.Scan(nil); err != nil || *id != nil {
+		t.Errorf("Expected nil IntDictionary and no error, got %v and %v", *id, err)
+	}
+}
+
+func TestNewCustomTimeStripsMonotonic(t *testing.T) {
+	now := time.Now()
+	a := NewCustomTime(now)
+	b := NewCustomTime(now.Round(0))
+	if *a != *b {
+		t.Errorf("Expected equal CustomTime values, got %v and %v", a.Time, b.Time)
+	}
+	if a.Time.String() != now.Round(0).String() {
+		t.Errorf("Expected monotonic reading to be stripped, got %s", a.Time.String())
+	}
+
+	v, _ := a.Value()
+	if v.(time.Time) != now.Round(0) {
+		t.Errorf("Expected Value %v, got %v", now.Round(0), v)
+	}
+}
+
+// timeResponseOf builds the TimeResponse CustomTime.MarshalJSON describes.
+func timeResponseOf(ct CustomTime) TimeResponse {
+	t := ct.Time
+	tr := TimeResponse{
+		ISO:    t.Format(time.RFC3339Nano),
+		TZ:     t.Location().String(),
+		Unix:   t.Unix(),
+		UnixMS: t.UnixMilli(),
+		US:     int64(t.Nanosecond()),
+		Full:   t.UnixMicro(),
+	}
+	if localizedTimeLocale != "" {
+		tr.Localized = ct.Format(localizedTimeLocale, localizedTimeStyle)
+	}
+	if relativeTimeOutput {
+		tr.Relative = ct.Humanize()
+	}
+	return tr
+}
+
+func TestCustomTimeMarshalJSONMatchesTimeResponse(t *testing.T) {
+	defer SetTimeLocalizedOutput("", TimeStyleMedium)
+	defer SetTimeRelativeOutput(false)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		paris = time.FixedZone("CET", 3600)
+	}
+	times := []time.Time{
+		time.Unix(0, 0).UTC(),
+		time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.FixedZone("a\"b", -3600)),
+		time.Date(2024, 2, 29, 8, 0, 0, 500, paris),
+	}
+	for _, output := range []bool{false, true} {
+		if output {
+			SetTimeLocalizedOutput("en-US", TimeStyleLong)
+			SetTimeRelativeOutput(true)
+		}
+		for _, tm := range times {
+			ct := *NewCustomTime(tm)
+			got, err := ct.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := json.Marshal(timeResponseOf(ct))
+			if string(got) != string(want) {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		}
+	}
+}
+
+func BenchmarkCustomTimeMarshalJSON(b *testing.B) {
+	ct := *NewCustomTime(time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC))
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ct.MarshalJSON()
+		}
+	})
+	b.Run("TimeResponse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			json.Marshal(timeResponseOf(ct))
+		}
+	})
+}
+
+func TestNullFloat64MarshalJSONUnsupported(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewNullFloat64(f).MarshalJSON(); err == nil {
+			t.Errorf("Expected an error for %v", f)
+		}
+	}
+}
+
+func TestIntDictionaryMarshalJSON(t *testing.T) {
+	tests := []IntDictionary{
+		nil,
+		{},
+		{"b": 2, "a": -1, "c": 12345},
+		{"<tag>": 1, "é": 2, "quote\"": 3},
+	}
+	for _, id := range tests {
+		got, err := json.Marshal(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := json.Marshal(map[string]int(id))
+		if string(got) != string(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func BenchmarkNullFloat64MarshalJSON(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewNullFloat64(float64(i % 1000)).MarshalJSON()
+	}
+}
+
+func BenchmarkIntDictionaryMarshalJSON(b *testing.B) {
+	id := IntDictionary{"en": 1, "fr": 2, "de": 3, "es": 40, "it": 500, "ja": -6}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		id.MarshalJSON()
+	}
+}
@@ -0,0 +1,93 @@
+// paged.go
+package octypes
+
+import "encoding/json"
+
+// KeyStyle selects the JSON key naming of envelopes such as PagedResult.
+type KeyStyle uint8
+
+const (
+	// KeySnakeCase writes keys such as "page_no". This is the default.
+	KeySnakeCase KeyStyle = iota
+	// KeyCamelCase writes keys such as "pageNo".
+	KeyCamelCase
+)
+
+// PagedResult is a page of items with its Pagination, marshalled as
+// {"items": [...], "pagination": {...}}. Nil Items marshal as an empty
+// array.
+type PagedResult[T any] struct {
+	Items      []T
+	Pagination Pagination
+	// KeyStyle selects the pagination keys. UnmarshalJSON accepts both.
+	KeyStyle KeyStyle
+}
+
+// NewPagedResult creates a new PagedResult with snake_case keys.
+func NewPagedResult[T any](items []T, p Pagination) PagedResult[T] {
+	return PagedResult[T]{Items: items, Pagination: p}
+}
+
+// paginationCamel is Pagination with camelCase keys.
+type paginationCamel struct {
+	PageNo         int `json:"pageNo"`
+	ResultsPerPage int `json:"resultsPerPage"`
+	PageMax        int `json:"pageMax"`
+	Count          int `json:"count"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r PagedResult[T]) MarshalJSON() ([]byte, error) {
+	items := r.Items
+	if items == nil {
+		items = []T{}
+	}
+	var p interface{} = r.Pagination
+	if r.KeyStyle == KeyCamelCase {
+		p = paginationCamel(r.Pagination)
+	}
+	return json.Marshal(struct {
+		Items      []T         `json:"items"`
+		Pagination interface{} `json:"pagination"`
+	}{items, p})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *PagedResult[T]) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Items      []T `json:"items"`
+		Pagination struct {
+			Pagination
+			PageNo         *int `json:"pageNo"`
+			ResultsPerPage *int `json:"resultsPerPage"`
+			PageMax        *int `json:"pageMax"`
+		} `json:"pagination"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	p := v.Pagination
+	r.Items = v.Items
+	r.Pagination = p.Pagination
+	r.KeyStyle = KeySnakeCase
+	if p.PageNo != nil || p.ResultsPerPage != nil || p.PageMax != nil {
+		r.KeyStyle = KeyCamelCase
+		r.Pagination.PageNo = derefInt(p.PageNo)
+		r.Pagination.ResultsPerPage = derefInt(p.ResultsPerPage)
+		r.Pagination.PageMax = derefInt(p.PageMax)
+	}
+	return nil
+}
+
+func derefInt(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// MarshalPaged marshals items and p as a PagedResult envelope with the
+// given key style.
+func MarshalPaged[T any](items []T, p Pagination, style KeyStyle) ([]byte, error) {
+	return json.Marshal(PagedResult[T]{Items: items, Pagination: p, KeyStyle: style})
+}
@@ -0,0 +1,41 @@
+// paged_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPagedResult(t *testing.T) {
+	p := Pagination{PageNo: 2, ResultsPerPage: 10, PageMax: 5, Count: 42}
+
+	b, err := json.Marshal(NewPagedResult([]string{"a", "b"}, p))
+	want := `{"items":["a","b"],"pagination":{"page_no":2,"results_per_page":10,"page_max":5,"count":42}}`
+	if err != nil || string(b) != want {
+		t.Errorf("Expected %s, got %s and error %v", want, b, err)
+	}
+
+	b, err = MarshalPaged[int](nil, p, KeyCamelCase)
+	want = `{"items":[],"pagination":{"pageNo":2,"resultsPerPage":10,"pageMax":5,"count":42}}`
+	if err != nil || string(b) != want {
+		t.Errorf("Expected %s, got %s and error %v", want, b, err)
+	}
+
+	var r PagedResult[int]
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatalf("Error unmarshalling: %v", err)
+	}
+	if r.Pagination != p || r.KeyStyle != KeyCamelCase || len(r.Items) != 0 {
+		t.Errorf("Expected camelCase pagination %+v, got %+v", p, r)
+	}
+
+	var rs PagedResult[NullString]
+	if err := json.Unmarshal([]byte(`{"items":["x",null],"pagination":{"page_no":1,"count":2}}`), &rs); err != nil {
+		t.Fatalf("Error unmarshalling: %v", err)
+	}
+	wantItems := []NullString{*NewNullString("x"), {}}
+	if !reflect.DeepEqual(rs.Items, wantItems) || rs.Pagination.PageNo != 1 || rs.KeyStyle != KeySnakeCase {
+		t.Errorf("Expected snake_case result, got %+v", rs)
+	}
+}
@@ -0,0 +1,201 @@
+// pagination.go
+package octypes
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaginationDefaults is the pagination policy of an application, set once
+// with SetPaginationDefaults and consulted by NewPagination, ParsePagination
+// and Pagination.Offset.
+type PaginationDefaults struct {
+	// PerPage is the page size used when none is given. Default 20.
+	PerPage int
+	// MaxPerPage caps page sizes; larger ones are clamped. Default 100.
+	MaxPerPage int
+	// ZeroBasedPages numbers the first page 0 instead of 1.
+	ZeroBasedPages bool
+}
+
+// firstPage returns the number of the first page under the policy.
+func (d PaginationDefaults) firstPage() int {
+	if d.ZeroBasedPages {
+		return 0
+	}
+	return 1
+}
+
+// NewPagination creates a Pagination for page pageNo of perPage rows out of
+// count, filling PageMax with the number of pages. A pageNo before the
+// first page selects the first page, a perPage of zero or less the default
+// page size, and page sizes above the maximum are clamped.
+func NewPagination(pageNo, perPage, count int) Pagination {
+	d := paginationDefaults
+	if pageNo < d.firstPage() {
+		pageNo = d.firstPage()
+	}
+	if perPage <= 0 {
+		perPage = d.PerPage
+	}
+	if perPage > d.MaxPerPage {
+		perPage = d.MaxPerPage
+	}
+	return Pagination{
+		PageNo:         pageNo,
+		ResultsPerPage: perPage,
+		PageMax:        (count + perPage - 1) / perPage,
+		Count:          count,
+	}
+}
+
+// Limit returns the number of rows of a page, never negative.
+func (p Pagination) Limit() int {
+	if p.ResultsPerPage < 0 {
+		return 0
+	}
+	return p.ResultsPerPage
+}
+
+// Offset returns the number of rows before the page. PageNo is 1-based
+// unless PaginationDefaults.ZeroBasedPages is set; values before the first
+// page are treated as the first page.
+func (p Pagination) Offset() int {
+	first := paginationDefaults.firstPage()
+	if p.PageNo < first {
+		return 0
+	}
+	return (p.PageNo - first) * p.Limit()
+}
+
+// SQLClause returns "LIMIT <n> OFFSET <n+1>" with bind parameters formatted
+// by placeholder, starting at n, and the matching arguments. A nil
+// placeholder uses PlaceholderDollar.
+func (p Pagination) SQLClause(placeholder Placeholder, n int) (string, []interface{}) {
+	if placeholder == nil {
+		placeholder = PlaceholderDollar
+	}
+	return "LIMIT " + placeholder(n) + " OFFSET " + placeholder(n+1), []interface{}{p.Limit(), p.Offset()}
+}
+
+// Errors wrapped by PaginationError.
+var (
+	ErrPaginationSyntax = errors.New("not an integer")
+	ErrPaginationRange  = errors.New("out of range")
+)
+
+// PaginationError reports an invalid pagination query parameter.
+type PaginationError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *PaginationError) Error() string {
+	return fmt.Sprintf("invalid pagination parameter %s=%q: %v", e.Param, e.Value, e.Err)
+}
+
+func (e *PaginationError) Unwrap() error {
+	return e.Err
+}
+
+// PaginationOptions configures ParsePagination. Zero fields use the
+// defaults documented on each field.
+type PaginationOptions struct {
+	// PageParam is the page number parameter, "page" by default.
+	PageParam string
+	// PerPageParam is the page size parameter, "per_page" by default.
+	PerPageParam string
+	// DefaultPerPage is used when PerPageParam is absent. It defaults to
+	// PaginationDefaults.PerPage.
+	DefaultPerPage int
+	// MaxPerPage caps the page size; larger values are clamped to it. It
+	// defaults to PaginationDefaults.MaxPerPage.
+	MaxPerPage int
+}
+
+// ParsePagination reads the page number, 1-based unless
+// PaginationDefaults.ZeroBasedPages is set, and the page size from query
+// parameters. Absent or empty parameters take their defaults and page sizes
+// above the maximum are clamped. Malformed values, pages before the first
+// and page sizes below 1 fail with a *PaginationError.
+func ParsePagination(values url.Values, opts PaginationOptions) (Pagination, error) {
+	if opts.PageParam == "" {
+		opts.PageParam = "page"
+	}
+	if opts.PerPageParam == "" {
+		opts.PerPageParam = "per_page"
+	}
+	d := paginationDefaults
+	if opts.DefaultPerPage <= 0 {
+		opts.DefaultPerPage = d.PerPage
+	}
+	if opts.MaxPerPage <= 0 {
+		opts.MaxPerPage = d.MaxPerPage
+	}
+
+	page, err := parsePaginationParam(values, opts.PageParam, d.firstPage(), d.firstPage())
+	if err != nil {
+		return Pagination{}, err
+	}
+	perPage, err := parsePaginationParam(values, opts.PerPageParam, opts.DefaultPerPage, 1)
+	if err != nil {
+		return Pagination{}, err
+	}
+	if perPage > opts.MaxPerPage {
+		perPage = opts.MaxPerPage
+	}
+	return Pagination{PageNo: page, ResultsPerPage: perPage}, nil
+}
+
+// parsePaginationParam returns the integer parameter param, which must be
+// at least minValue, or def if it is absent or empty.
+func parsePaginationParam(values url.Values, param string, def, minValue int) (int, error) {
+	s := strings.TrimSpace(values.Get(param))
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, &PaginationError{Param: param, Value: s, Err: ErrPaginationRange}
+		}
+		return 0, &PaginationError{Param: param, Value: s, Err: ErrPaginationSyntax}
+	}
+	if n < minValue {
+		return 0, &PaginationError{Param: param, Value: s, Err: ErrPaginationRange}
+	}
+	return n, nil
+}
+
+// CursorPagination describes a page of a query paginated without a total
+// count. Fetch FetchLimit rows, then call TrimPage to drop the extra row
+// and set HasMore. NextCursor is typically an EncodeKeyset token of the
+// last row returned.
+type CursorPagination struct {
+	ResultsPerPage int    `json:"results_per_page"`
+	HasMore        bool   `json:"has_more"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+}
+
+// FetchLimit returns the number of rows to fetch: one more than the page
+// size, so the presence of a next page is known without counting.
+func (c CursorPagination) FetchLimit() int {
+	if c.ResultsPerPage < 0 {
+		return 1
+	}
+	return c.ResultsPerPage + 1
+}
+
+// TrimPage returns the first c.ResultsPerPage items of rows fetched with
+// c.FetchLimit and sets c.HasMore if there were more.
+func TrimPage[T any](rows []T, c *CursorPagination) []T {
+	c.HasMore = len(rows) > c.ResultsPerPage
+	if c.HasMore {
+		return rows[:max(c.ResultsPerPage, 0)]
+	}
+	return rows
+}
@@ -0,0 +1,132 @@
+// pagination_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestPaginationLimitOffset(t *testing.T) {
+	tests := []struct {
+		p             Pagination
+		limit, offset int
+	}{
+		{Pagination{PageNo: 1, ResultsPerPage: 20}, 20, 0},
+		{Pagination{PageNo: 3, ResultsPerPage: 20}, 20, 40},
+		{Pagination{PageNo: 0, ResultsPerPage: 10}, 10, 0},
+		{Pagination{PageNo: 2, ResultsPerPage: -5}, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Limit(); got != tt.limit {
+			t.Errorf("Expected limit %d for %+v, got %d", tt.limit, tt.p, got)
+		}
+		if got := tt.p.Offset(); got != tt.offset {
+			t.Errorf("Expected offset %d for %+v, got %d", tt.offset, tt.p, got)
+		}
+	}
+
+	p := Pagination{PageNo: 3, ResultsPerPage: 20}
+	clause, args := p.SQLClause(nil, 2)
+	if clause != "LIMIT $2 OFFSET $3" || !reflect.DeepEqual(args, []interface{}{20, 40}) {
+		t.Errorf("Expected LIMIT $2 OFFSET $3 [20 40], got %s %v", clause, args)
+	}
+	if clause, _ := p.SQLClause(PlaceholderQuestion, 1); clause != "LIMIT ? OFFSET ?" {
+		t.Errorf("Expected LIMIT ? OFFSET ?, got %s", clause)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query string
+		opts  PaginationOptions
+		want  Pagination
+	}{
+		{"", PaginationOptions{}, Pagination{PageNo: 1, ResultsPerPage: 20}},
+		{"page=3&per_page=50", PaginationOptions{}, Pagination{PageNo: 3, ResultsPerPage: 50}},
+		{"page=2&per_page=500", PaginationOptions{}, Pagination{PageNo: 2, ResultsPerPage: 100}},
+		{"p=4&size=", PaginationOptions{PageParam: "p", PerPageParam: "size", DefaultPerPage: 10}, Pagination{PageNo: 4, ResultsPerPage: 10}},
+		{"per_page=30", PaginationOptions{MaxPerPage: 25}, Pagination{PageNo: 1, ResultsPerPage: 25}},
+	}
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		got, err := ParsePagination(values, tt.opts)
+		if err != nil || got != tt.want {
+			t.Errorf("Expected %+v for %q, got %+v and error %v", tt.want, tt.query, got, err)
+		}
+	}
+
+	errTests := []struct {
+		query, param string
+		err          error
+	}{
+		{"page=abc", "page", ErrPaginationSyntax},
+		{"page=0", "page", ErrPaginationRange},
+		{"per_page=-5", "per_page", ErrPaginationRange},
+		{"page=99999999999999999999", "page", ErrPaginationRange},
+	}
+	for _, tt := range errTests {
+		values, _ := url.ParseQuery(tt.query)
+		_, err := ParsePagination(values, PaginationOptions{})
+		var perr *PaginationError
+		if !errors.As(err, &perr) || perr.Param != tt.param || !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v on %s for %q, got %v", tt.err, tt.param, tt.query, err)
+		}
+	}
+}
+
+func TestCursorPagination(t *testing.T) {
+	c := CursorPagination{ResultsPerPage: 3}
+	if c.FetchLimit() != 4 {
+		t.Errorf("Expected fetch limit 4, got %d", c.FetchLimit())
+	}
+
+	page := TrimPage([]int{1, 2, 3, 4}, &c)
+	if !reflect.DeepEqual(page, []int{1, 2, 3}) || !c.HasMore {
+		t.Errorf("Expected [1 2 3] with more, got %v and %v", page, c.HasMore)
+	}
+	page = TrimPage([]int{1, 2}, &c)
+	if !reflect.DeepEqual(page, []int{1, 2}) || c.HasMore {
+		t.Errorf("Expected [1 2] without more, got %v and %v", page, c.HasMore)
+	}
+
+	c = CursorPagination{ResultsPerPage: 2}
+	TrimPage([]string{"a", "b", "c"}, &c)
+	c.NextCursor = "abc"
+	b, _ := json.Marshal(c)
+	if string(b) != `{"results_per_page":2,"has_more":true,"next_cursor":"abc"}` {
+		t.Errorf("Unexpected JSON: %s", b)
+	}
+}
+
+func TestPaginationDefaults(t *testing.T) {
+	defer SetPaginationDefaults(PaginationDefaults{})
+
+	if got := NewPagination(0, 0, 45); got != (Pagination{PageNo: 1, ResultsPerPage: 20, PageMax: 3, Count: 45}) {
+		t.Errorf("Unexpected default pagination: %+v", got)
+	}
+	if got := NewPagination(2, 500, 0); got != (Pagination{PageNo: 2, ResultsPerPage: 100}) {
+		t.Errorf("Unexpected clamped pagination: %+v", got)
+	}
+
+	SetPaginationDefaults(PaginationDefaults{PerPage: 10, MaxPerPage: 50, ZeroBasedPages: true})
+	if got := NewPagination(-1, 0, 10); got != (Pagination{PageNo: 0, ResultsPerPage: 10, PageMax: 1, Count: 10}) {
+		t.Errorf("Unexpected zero-based pagination: %+v", got)
+	}
+	if got := (Pagination{PageNo: 2, ResultsPerPage: 10}).Offset(); got != 20 {
+		t.Errorf("Expected zero-based offset 20, got %d", got)
+	}
+
+	got, err := ParsePagination(url.Values{"page": {"0"}, "per_page": {"80"}}, PaginationOptions{})
+	if err != nil || got != (Pagination{PageNo: 0, ResultsPerPage: 50}) {
+		t.Errorf("Unexpected parsed pagination: %+v and error %v", got, err)
+	}
+	if got, _ := ParsePagination(url.Values{}, PaginationOptions{}); got.ResultsPerPage != 10 || got.PageNo != 0 {
+		t.Errorf("Expected registry defaults, got %+v", got)
+	}
+	if _, err := ParsePagination(url.Values{"page": {"-1"}}, PaginationOptions{}); !errors.Is(err, ErrPaginationRange) {
+		t.Errorf("Expected ErrPaginationRange, got %v", err)
+	}
+}
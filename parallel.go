@@ -0,0 +1,180 @@
+// parallel.go
+package octypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// ParallelOptions configures the parallel encoding helpers.
+type ParallelOptions struct {
+	// Workers is the number of goroutines. It defaults to GOMAXPROCS.
+	Workers int
+	// Threshold is the number of items below which work is done on the
+	// calling goroutine, where splitting costs more than it saves. It
+	// defaults to 10000.
+	Threshold int
+}
+
+// chunks splits n items into contiguous ranges for the workers, or returns
+// nil if n is below the threshold.
+func (o ParallelOptions) chunks(n int) [][2]int {
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = 10000
+	}
+	if n < o.Threshold || o.Workers < 2 {
+		return nil
+	}
+	workers := min(o.Workers, n)
+	ranges := make([][2]int, workers)
+	for w := range ranges {
+		ranges[w] = [2]int{n * w / workers, n * (w + 1) / workers}
+	}
+	return ranges
+}
+
+// runChunks calls f for each range on its own goroutine and returns the
+// error of the first range that failed.
+func runChunks(ranges [][2]int, f func(w, lo, hi int) error) error {
+	errs := make([]error, len(ranges))
+	var wg sync.WaitGroup
+	for w, r := range ranges {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[w] = f(w, r[0], r[1])
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParallelAppend appends the encodings of items to dst, in order, with
+// appendItem called from several goroutines once there are at least
+// opts.Threshold items. Each worker encodes a contiguous range into its
+// own buffer and the buffers are stitched together, so appendItem can
+// produce any format, JSON or binary, that concatenates. It must be safe
+// for concurrent use.
+func ParallelAppend[T any](dst []byte, items []T, opts ParallelOptions, appendItem func(dst []byte, item T) ([]byte, error)) ([]byte, error) {
+	ranges := opts.chunks(len(items))
+	if ranges == nil {
+		var err error
+		for _, item := range items {
+			if dst, err = appendItem(dst, item); err != nil {
+				return dst, err
+			}
+		}
+		return dst, nil
+	}
+	bufs := make([][]byte, len(ranges))
+	err := runChunks(ranges, func(w, lo, hi int) error {
+		var err error
+		for _, item := range items[lo:hi] {
+			if bufs[w], err = appendItem(bufs[w], item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return dst, err
+	}
+	return stitch(dst, bufs), nil
+}
+
+// stitch appends bufs to dst, growing it once.
+func stitch(dst []byte, bufs [][]byte) []byte {
+	n := len(dst)
+	for _, b := range bufs {
+		n += len(b)
+	}
+	if n > cap(dst) {
+		dst = append(make([]byte, 0, n), dst...)
+	}
+	for _, b := range bufs {
+		dst = append(dst, b...)
+	}
+	return dst
+}
+
+// MarshalSliceParallel is MarshalSlice spread over several goroutines for
+// slices of at least opts.Threshold items. The output is the same.
+func MarshalSliceParallel(dst []byte, items interface{}, opts ParallelOptions) ([]byte, error) {
+	rv := reflect.ValueOf(items)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+		return MarshalSlice(dst, items)
+	}
+	ranges := opts.chunks(rv.Len())
+	if ranges == nil {
+		return MarshalSlice(dst, items)
+	}
+	enc := sliceEncoderFor(rv.Type().Elem())
+	bufs := make([][]byte, len(ranges))
+	err := runChunks(ranges, func(w, lo, hi int) error {
+		buf := make([]byte, 0, 64*(hi-lo))
+		for i := lo; i < hi; i++ {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = enc(buf, rv.Index(i)); err != nil {
+				return fmt.Errorf("MarshalSlice: element %d: %w", i, err)
+			}
+		}
+		bufs[w] = buf
+		return nil
+	})
+	if err != nil {
+		return dst, err
+	}
+	dst = append(dst, '[')
+	return append(stitch(dst, bufs), ']'), nil
+}
+
+// UnmarshalSliceParallel decodes the JSON array data into dst, decoding
+// elements on several goroutines when there are at least opts.Threshold
+// of them. The array is first split into its elements, then each element
+// is decoded with json.Unmarshal, so the element type must not depend on
+// decoding order. A JSON null sets dst to nil.
+func UnmarshalSliceParallel[T any](data []byte, dst *[]T, opts ParallelOptions) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+	if raws == nil {
+		*dst = nil
+		return nil
+	}
+	out := make([]T, len(raws))
+	decode := func(lo, hi int) error {
+		for i := lo; i < hi; i++ {
+			if err := json.Unmarshal(raws[i], &out[i]); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+	ranges := opts.chunks(len(raws))
+	var err error
+	if ranges == nil {
+		err = decode(0, len(raws))
+	} else {
+		err = runChunks(ranges, func(_, lo, hi int) error { return decode(lo, hi) })
+	}
+	if err != nil {
+		return err
+	}
+	*dst = out
+	return nil
+}
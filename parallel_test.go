@@ -0,0 +1,93 @@
+// parallel_test.go
+package octypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalSliceParallel(t *testing.T) {
+	rows := benchmarkSliceRows()
+	want, _ := MarshalSlice(nil, rows)
+	for _, opts := range []ParallelOptions{{}, {Threshold: 10, Workers: 3}, {Threshold: 1, Workers: 2000}} {
+		got, err := MarshalSliceParallel([]byte("x"), rows, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "x"+string(want) {
+			t.Errorf("Expected the MarshalSlice output with %+v", opts)
+		}
+	}
+	got, _ := MarshalSliceParallel(nil, []sliceRow{}, ParallelOptions{Threshold: 1})
+	if string(got) != "[]" {
+		t.Errorf("Expected [], got %s", got)
+	}
+
+	bad := []NullFloat64{*NewNullFloat64(1), *NewNullFloat64(2), {}, *NewNullFloat64(0)}
+	bad[3].Float64 = 1 / bad[3].Float64
+	if _, err := MarshalSliceParallel(nil, bad, ParallelOptions{Threshold: 1, Workers: 2}); err == nil || !strings.Contains(err.Error(), "element 3") {
+		t.Errorf("Expected an error for element 3, got %v", err)
+	}
+}
+
+func TestParallelAppend(t *testing.T) {
+	times := make([]CustomTime, 100)
+	var want []byte
+	for i := range times {
+		times[i] = *NewCustomTime(time.Unix(int64(i), 0).UTC())
+		b, _ := times[i].MarshalBinary()
+		want = append(want, b...)
+	}
+	appendBinary := func(dst []byte, ct CustomTime) ([]byte, error) {
+		b, err := ct.MarshalBinary()
+		return append(dst, b...), err
+	}
+	for _, opts := range []ParallelOptions{{}, {Threshold: 10, Workers: 7}} {
+		got, err := ParallelAppend(nil, times, opts, appendBinary)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("Expected the sequential output with %+v, got error %v", opts, err)
+		}
+	}
+}
+
+func TestUnmarshalSliceParallel(t *testing.T) {
+	rows := benchmarkSliceRows()
+	data, _ := json.Marshal(rows)
+	for _, opts := range []ParallelOptions{{}, {Threshold: 10, Workers: 4}} {
+		var got []sliceRow
+		if err := UnmarshalSliceParallel(data, &got, opts); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(rows) || got[999].ID != rows[999].ID || !got[5].Created.Time.Equal(rows[5].Created.Time) {
+			t.Errorf("Unexpected rows with %+v", opts)
+		}
+	}
+	var got []NullInt64
+	if err := UnmarshalSliceParallel([]byte(`[1,"x",3]`), &got, ParallelOptions{Threshold: 1}); err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("Expected an error for element 1, got %v", err)
+	}
+	got = []NullInt64{{}}
+	if err := UnmarshalSliceParallel([]byte(`null`), &got, ParallelOptions{}); err != nil || got != nil {
+		t.Errorf("Expected nil, got %v, %v", got, err)
+	}
+}
+
+func BenchmarkMarshalSliceParallel(b *testing.B) {
+	rows := make([]sliceRow, 0, 100000)
+	for len(rows) < cap(rows) {
+		rows = append(rows, benchmarkSliceRows()...)
+	}
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MarshalSlice(nil, rows)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MarshalSliceParallel(nil, rows, ParallelOptions{})
+		}
+	})
+}
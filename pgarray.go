@@ -0,0 +1,261 @@
+// pgarray.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseArray parses a one-dimensional Postgres array literal such as
+// {a,"b c",NULL}. NULL elements are returned as invalid NullStrings.
+func ParseArray(src string) ([]NullString, error) {
+	s := strings.TrimSpace(src)
+	// Skip an explicit dimension decoration such as "[0:2]=".
+	if strings.HasPrefix(s, "[") {
+		i := strings.IndexByte(s, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid array literal %q", src)
+		}
+		s = s[i+1:]
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("invalid array literal %q", src)
+	}
+	s = s[1 : len(s)-1]
+
+	elems := []NullString{}
+	if strings.TrimSpace(s) == "" {
+		return elems, nil
+	}
+
+	var buf strings.Builder
+	for i := 0; ; {
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("invalid array literal %q: missing element", src)
+		}
+
+		buf.Reset()
+		if s[i] == '"' {
+			i++
+			closed := false
+			for i < len(s) {
+				c := s[i]
+				i++
+				if c == '\\' && i < len(s) {
+					buf.WriteByte(s[i])
+					i++
+					continue
+				}
+				if c == '"' {
+					closed = true
+					break
+				}
+				buf.WriteByte(c)
+			}
+			if !closed {
+				return nil, fmt.Errorf("invalid array literal %q: unterminated quote", src)
+			}
+			for i < len(s) && isArraySpace(s[i]) {
+				i++
+			}
+			elems = append(elems, *NewNullStringAllowEmpty(buf.String()))
+		} else {
+			// Trailing whitespace of unquoted elements is not significant,
+			// but escaped whitespace is.
+			end, escaped := 0, false
+			for i < len(s) && s[i] != ',' {
+				c := s[i]
+				i++
+				switch {
+				case c == '{' || c == '}' || c == '"':
+					return nil, fmt.Errorf("invalid array literal %q: unexpected %q", src, c)
+				case c == '\\' && i < len(s):
+					buf.WriteByte(s[i])
+					i++
+					end, escaped = buf.Len(), true
+				default:
+					buf.WriteByte(c)
+					if !isArraySpace(c) {
+						end = buf.Len()
+					}
+				}
+			}
+			if end == 0 && !escaped {
+				return nil, fmt.Errorf("invalid array literal %q: missing element", src)
+			}
+			elem := buf.String()[:end]
+			if !escaped && strings.EqualFold(elem, "NULL") {
+				elems = append(elems, NullString{})
+			} else {
+				elems = append(elems, *NewNullStringAllowEmpty(elem))
+			}
+		}
+
+		if i == len(s) {
+			return elems, nil
+		}
+		if s[i] != ',' {
+			return nil, fmt.Errorf("invalid array literal %q: expected ','", src)
+		}
+		i++
+	}
+}
+
+// FormatArray formats elements as a Postgres array literal, quoting and
+// escaping them as needed. Invalid elements are written as NULL.
+func FormatArray(elems []NullString) string {
+	b := defaultBufferPool.Get(0)
+	*b = AppendArray(*b, elems)
+	s := string(*b)
+	defaultBufferPool.Put(b)
+	return s
+}
+
+// AppendArray appends the Postgres array literal of elems to dst.
+func AppendArray(dst []byte, elems []NullString) []byte {
+	dst = append(dst, '{')
+	for i, e := range elems {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if !e.Valid {
+			dst = append(dst, "NULL"...)
+			continue
+		}
+		dst = appendArrayElem(dst, e.String)
+	}
+	return append(dst, '}')
+}
+
+func appendArrayElem(dst []byte, s string) []byte {
+	if !arrayElemNeedsQuotes(s) {
+		return append(dst, s...)
+	}
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			dst = append(dst, '\\')
+		}
+		dst = append(dst, s[i])
+	}
+	return append(dst, '"')
+}
+
+func arrayElemNeedsQuotes(s string) bool {
+	if s == "" || strings.EqualFold(s, "NULL") {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{', '}', ',', '"', '\\':
+			return true
+		}
+		if isArraySpace(s[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// arrayScanSource returns the array literal held by a Scan source.
+func arrayScanSource(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+	return "", errors.New("Scan source is not []byte or string")
+}
+
+// NullStringArray represents a Postgres text[] whose elements may be null.
+// A nil slice is a null array.
+type NullStringArray []NullString
+
+// Scan implements the sql.Scanner interface.
+func (a *NullStringArray) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	src, err := arrayScanSource(value)
+	if err != nil {
+		return err
+	}
+	elems, err := ParseArray(src)
+	if err != nil {
+		return err
+	}
+	*a = elems
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a NullStringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return FormatArray(a), nil
+}
+
+// NullInt64Array represents a Postgres bigint[] whose elements may be null.
+// A nil slice is a null array.
+type NullInt64Array []NullInt64
+
+// Scan implements the sql.Scanner interface.
+func (a *NullInt64Array) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	src, err := arrayScanSource(value)
+	if err != nil {
+		return err
+	}
+	elems, err := ParseArray(src)
+	if err != nil {
+		return err
+	}
+	arr := make(NullInt64Array, len(elems))
+	for i, e := range elems {
+		if !e.Valid {
+			continue
+		}
+		n, err := parseIntText(e.String)
+		if err != nil {
+			return fmt.Errorf("array element %d: %w", i, err)
+		}
+		arr[i] = *NewNullInt64(n)
+	}
+	*a = arr
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a NullInt64Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	dst := []byte{'{'}
+	for i, e := range a {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if !e.Valid {
+			dst = append(dst, "NULL"...)
+			continue
+		}
+		dst = strconv.AppendInt(dst, e.Int64, 10)
+	}
+	return string(append(dst, '}')), nil
+}
@@ -0,0 +1,133 @@
+// pgarray_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseArray(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []NullString
+	}{
+		{`{}`, []NullString{}},
+		{`{a,b}`, []NullString{*NewNullString("a"), *NewNullString("b")}},
+		{`{ a , b c }`, []NullString{*NewNullString("a"), *NewNullString("b c")}},
+		{`{"a,b","say \"hi\"","back\\slash"}`, []NullString{*NewNullString("a,b"), *NewNullString(`say "hi"`), *NewNullString(`back\slash`)}},
+		{`{NULL,null,"NULL",\NULL}`, []NullString{{}, {}, *NewNullString("NULL"), *NewNullString("NULL")}},
+		{`{""}`, []NullString{*NewNullStringAllowEmpty("")}},
+		{`[1:2]={x,y}`, []NullString{*NewNullString("x"), *NewNullString("y")}},
+	}
+	for _, tt := range tests {
+		got, err := ParseArray(tt.in)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Expected %d elements for %s, got %d", len(tt.want), tt.in, len(got))
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Element %d of %s: expected %+v, got %+v", i, tt.in, tt.want[i], got[i])
+			}
+		}
+	}
+}
+
+func TestParseArrayInvalid(t *testing.T) {
+	for _, in := range []string{``, `a,b`, `{a,}`, `{a,,b}`, `{"a}`, `{{a}}`, `{a"b}`, `{"a" b}`} {
+		if _, err := ParseArray(in); err == nil {
+			t.Errorf("Expected error when parsing %q, got nil", in)
+		}
+	}
+}
+
+func TestFormatArray(t *testing.T) {
+	elems := []NullString{
+		*NewNullString("plain"),
+		*NewNullStringAllowEmpty(""),
+		{},
+		*NewNullString("null"),
+		*NewNullString(`a "q", \b`),
+		*NewNullString("{x}"),
+	}
+	want := `{plain,"",NULL,"null","a \"q\", \\b","{x}"}`
+	got := FormatArray(elems)
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	// Test round trip
+	parsed, err := ParseArray(got)
+	if err != nil {
+		t.Errorf("Error parsing formatted array: %v", err)
+	}
+	for i := range elems {
+		if parsed[i] != elems[i] {
+			t.Errorf("Element %d: expected %+v, got %+v", i, elems[i], parsed[i])
+		}
+	}
+}
+
+func TestNullStringArray(t *testing.T) {
+	var a NullStringArray
+	err := a.Scan([]byte(`{one,NULL,"two words"}`))
+	if err != nil {
+		t.Errorf("Error scanning NullStringArray: %v", err)
+	}
+	if len(a) != 3 || a[0].String != "one" || a[1].Valid || a[2].String != "two words" {
+		t.Errorf("Unexpected NullStringArray %+v", a)
+	}
+
+	val, err := a.Value()
+	if err != nil {
+		t.Errorf("Error getting Value from NullStringArray: %v", err)
+	}
+	if val != `{one,NULL,"two words"}` {
+		t.Errorf("Expected Value '{one,NULL,\"two words\"}', got '%v'", val)
+	}
+
+	jsonData, err := json.Marshal(a)
+	if err != nil {
+		t.Errorf("Error marshalling NullStringArray: %v", err)
+	}
+	if string(jsonData) != `["one",null,"two words"]` {
+		t.Errorf("Expected JSON '[\"one\",null,\"two words\"]', got %s", jsonData)
+	}
+
+	if err := a.Scan(nil); err != nil || a != nil {
+		t.Errorf("Expected nil NullStringArray and no error, got %v and %v", a, err)
+	}
+	if val, _ := a.Value(); val != nil {
+		t.Errorf("Expected nil Value, got %v", val)
+	}
+	if err := a.Scan(42); err == nil {
+		t.Errorf("Expected error when scanning int into NullStringArray, got nil")
+	}
+}
+
+func TestNullInt64Array(t *testing.T) {
+	var a NullInt64Array
+	err := a.Scan(`{1,NULL,-3}`)
+	if err != nil {
+		t.Errorf("Error scanning NullInt64Array: %v", err)
+	}
+	if len(a) != 3 || a[0].Int64 != 1 || a[1].Valid || a[2].Int64 != -3 {
+		t.Errorf("Unexpected NullInt64Array %+v", a)
+	}
+
+	val, err := a.Value()
+	if err != nil {
+		t.Errorf("Error getting Value from NullInt64Array: %v", err)
+	}
+	if val != `{1,NULL,-3}` {
+		t.Errorf("Expected Value '{1,NULL,-3}', got '%v'", val)
+	}
+
+	if err := a.Scan(`{1,x}`); err == nil {
+		t.Errorf("Expected error when scanning non-integer element, got nil")
+	}
+}
@@ -0,0 +1,150 @@
+// adapters.go
+package pgxutil
+
+import (
+	"errors"
+
+	"github.com/coffyg/octypes"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The adapters below hold a pointer to the octypes value so a single type
+// serves both as scan target and as encode value. A nil pointer encodes as
+// NULL.
+
+var errInfinity = errors.New("cannot scan infinite time into CustomTime")
+
+type nullString struct{ v *octypes.NullString }
+
+func (a nullString) ScanText(t pgtype.Text) error {
+	a.v.String, a.v.Valid = t.String, t.Valid
+	return nil
+}
+
+func (a nullString) TextValue() (pgtype.Text, error) {
+	if a.v == nil {
+		return pgtype.Text{}, nil
+	}
+	return pgtype.Text{String: a.v.String, Valid: a.v.Valid}, nil
+}
+
+type nullInt64 struct{ v *octypes.NullInt64 }
+
+func (a nullInt64) ScanInt64(n pgtype.Int8) error {
+	a.v.Int64, a.v.Valid = n.Int64, n.Valid
+	return nil
+}
+
+func (a nullInt64) Int64Value() (pgtype.Int8, error) {
+	if a.v == nil {
+		return pgtype.Int8{}, nil
+	}
+	return pgtype.Int8{Int64: a.v.Int64, Valid: a.v.Valid}, nil
+}
+
+type nullFloat64 struct{ v *octypes.NullFloat64 }
+
+func (a nullFloat64) ScanFloat64(n pgtype.Float8) error {
+	a.v.Float64, a.v.Valid = n.Float64, n.Valid
+	return nil
+}
+
+func (a nullFloat64) Float64Value() (pgtype.Float8, error) {
+	if a.v == nil {
+		return pgtype.Float8{}, nil
+	}
+	return pgtype.Float8{Float64: a.v.Float64, Valid: a.v.Valid}, nil
+}
+
+type nullBool struct{ v *octypes.NullBool }
+
+func (a nullBool) ScanBool(b pgtype.Bool) error {
+	a.v.Bool, a.v.Valid = b.Bool, b.Valid
+	return nil
+}
+
+func (a nullBool) BoolValue() (pgtype.Bool, error) {
+	if a.v == nil {
+		return pgtype.Bool{}, nil
+	}
+	return pgtype.Bool{Bool: a.v.Bool, Valid: a.v.Valid}, nil
+}
+
+type customTime struct{ v *octypes.CustomTime }
+
+func (a customTime) ScanTimestamptz(t pgtype.Timestamptz) error {
+	if t.Valid && t.InfinityModifier != pgtype.Finite {
+		return errInfinity
+	}
+	a.v.Time, a.v.Valid = t.Time, t.Valid
+	return nil
+}
+
+func (a customTime) TimestamptzValue() (pgtype.Timestamptz, error) {
+	if a.v == nil {
+		return pgtype.Timestamptz{}, nil
+	}
+	return pgtype.Timestamptz{Time: a.v.Time, Valid: a.v.Valid}, nil
+}
+
+func (a customTime) ScanTimestamp(t pgtype.Timestamp) error {
+	if t.Valid && t.InfinityModifier != pgtype.Finite {
+		return errInfinity
+	}
+	a.v.Time, a.v.Valid = t.Time, t.Valid
+	return nil
+}
+
+func (a customTime) TimestampValue() (pgtype.Timestamp, error) {
+	if a.v == nil {
+		return pgtype.Timestamp{}, nil
+	}
+	return pgtype.Timestamp{Time: a.v.Time, Valid: a.v.Valid}, nil
+}
+
+func (a customTime) ScanDate(d pgtype.Date) error {
+	if d.Valid && d.InfinityModifier != pgtype.Finite {
+		return errInfinity
+	}
+	a.v.Time, a.v.Valid = d.Time, d.Valid
+	return nil
+}
+
+func (a customTime) DateValue() (pgtype.Date, error) {
+	if a.v == nil {
+		return pgtype.Date{}, nil
+	}
+	return pgtype.Date{Time: a.v.Time, Valid: a.v.Valid}, nil
+}
+
+type nullBytes struct{ v *octypes.NullBytes }
+
+func (a nullBytes) ScanBytes(b []byte) error {
+	// b is only valid until the next scan, so keep a copy.
+	if b == nil {
+		a.v.Bytes, a.v.Valid = nil, false
+		return nil
+	}
+	a.v.Bytes, a.v.Valid = append([]byte{}, b...), true
+	return nil
+}
+
+func (a nullBytes) BytesValue() ([]byte, error) {
+	if a.v == nil || !a.v.Valid {
+		return nil, nil
+	}
+	return a.v.Bytes, nil
+}
+
+// localizedText decodes through UnmarshalJSON, so that scanned values get
+// the same key normalization, sanitization and interning as Scan and
+// UnmarshalJSON apply.
+type localizedText struct{ v *octypes.LocalizedText }
+
+func (a localizedText) ScanBytes(b []byte) error {
+	if b == nil {
+		*a.v = nil
+		return nil
+	}
+	return a.v.UnmarshalJSON(b)
+}
@@ -0,0 +1,29 @@
+// dbtest_test.go
+package pgxutil
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/coffyg/octypes/dbtest"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// TestPostgres runs the dbtest conformance suite through the pgx driver.
+// It lives in this module so that the root module does not depend on pgx.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("OCTYPES_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("OCTYPES_POSTGRES_DSN not set")
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatalf("Error connecting to database: %v", err)
+	}
+	dbtest.Run(t, db, dbtest.Postgres)
+}
@@ -0,0 +1,21 @@
+module github.com/coffyg/octypes/pgxutil
+
+go 1.23.0
+
+// The root module is used from this checkout, so the two are always
+// tested together.
+replace github.com/coffyg/octypes => ../
+
+require (
+	github.com/coffyg/octypes v0.0.0-00010101000000-000000000000
+	github.com/jackc/pgx/v5 v5.7.6
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/sync v0.13.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+)
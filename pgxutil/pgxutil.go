@@ -0,0 +1,189 @@
+// Package pgxutil registers native pgx v5 codecs for octypes.
+//
+// Without registration pgx falls back to the sql.Scanner and driver.Valuer
+// implementations of octypes, decoding every value into an intermediate
+// database/sql value first. Register teaches a pgtype.Map to scan binary
+// wire data straight into octypes values and to encode them without going
+// through Value:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxutil.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// pgxutil is a separate module, so that only applications importing it
+// depend on pgx.
+package pgxutil
+
+import (
+	"github.com/coffyg/octypes"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// wrappedTypes lists the PostgreSQL types whose codecs are taught to scan
+// into octypes values.
+var wrappedTypes = []string{
+	"text", "varchar", "bpchar", "name",
+	"int2", "int4", "int8",
+	"float4", "float8", "numeric",
+	"bool",
+	"date", "timestamp", "timestamptz",
+	"bytea",
+	"json", "jsonb",
+}
+
+// defaultPgTypes maps octypes values to the PostgreSQL type used when the
+// parameter type is not known in advance.
+var defaultPgTypes = []struct {
+	value any
+	name  string
+}{
+	{octypes.NullString{}, "text"},
+	{octypes.NullInt64{}, "int8"},
+	{octypes.NullFloat64{}, "float8"},
+	{octypes.NullBool{}, "bool"},
+	{octypes.CustomTime{}, "timestamptz"},
+	{octypes.NullBytes{}, "bytea"},
+	{octypes.LocalizedText{}, "jsonb"},
+	{octypes.IntDictionary{}, "jsonb"},
+}
+
+// Register registers octypes codecs on m. It is safe to call more than once
+// on the same map.
+func Register(m *pgtype.Map) {
+	for _, name := range wrappedTypes {
+		t, ok := m.TypeForName(name)
+		if !ok {
+			continue
+		}
+		if _, ok := t.Codec.(*codec); ok {
+			continue
+		}
+		m.RegisterType(&pgtype.Type{Name: t.Name, OID: t.OID, Codec: &codec{Codec: t.Codec}})
+	}
+
+	for _, d := range defaultPgTypes {
+		m.RegisterDefaultPgType(d.value, d.name)
+	}
+
+	for _, f := range m.TryWrapEncodePlanFuncs {
+		if isTryWrapEncodePlan(f) {
+			return
+		}
+	}
+	// Prepend so the wrapper runs before the built-in driver.Valuer fallback.
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapEncodePlan}, m.TryWrapEncodePlanFuncs...)
+}
+
+// codec wraps a built-in codec so that scan targets of octypes are
+// converted to adapters implementing the pgtype scanner interfaces before
+// the wrapped codec plans the scan. Without it pgx would choose the
+// sql.Scanner path for octypes targets.
+type codec struct {
+	pgtype.Codec
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c *codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if wrapped, ok := wrapScanTarget(target); ok {
+		if next := c.Codec.PlanScan(m, oid, format, wrapped); next != nil {
+			return &scanPlan{next: next}
+		}
+	}
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+type scanPlan struct {
+	next pgtype.ScanPlan
+}
+
+// Scan implements the pgtype.ScanPlan interface.
+func (p *scanPlan) Scan(src []byte, dst any) error {
+	wrapped, _ := wrapScanTarget(dst)
+	return p.next.Scan(src, wrapped)
+}
+
+// TryWrapEncodePlan is a pgtype.TryWrapEncodePlanFunc that converts octypes
+// values to adapters implementing the pgtype valuer interfaces. Register
+// installs it; it is exported for maps assembled by hand.
+func TryWrapEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	if wrapped, ok := wrapEncodeValue(value); ok {
+		return &encodePlan{}, wrapped, true
+	}
+	return nil, nil, false
+}
+
+func isTryWrapEncodePlan(f pgtype.TryWrapEncodePlanFunc) bool {
+	_, next, ok := f(octypes.NullBool{})
+	if !ok {
+		return false
+	}
+	_, ok = next.(nullBool)
+	return ok
+}
+
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+// SetNext implements the pgtype.WrappedEncodePlanNextSetter interface.
+func (p *encodePlan) SetNext(next pgtype.EncodePlan) { p.next = next }
+
+// Encode implements the pgtype.EncodePlan interface.
+func (p *encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	wrapped, _ := wrapEncodeValue(value)
+	return p.next.Encode(wrapped, buf)
+}
+
+func wrapScanTarget(target any) (any, bool) {
+	switch t := target.(type) {
+	case *octypes.NullString:
+		return nullString{t}, true
+	case *octypes.NullInt64:
+		return nullInt64{t}, true
+	case *octypes.NullFloat64:
+		return nullFloat64{t}, true
+	case *octypes.NullBool:
+		return nullBool{t}, true
+	case *octypes.CustomTime:
+		return customTime{t}, true
+	case *octypes.NullBytes:
+		return nullBytes{t}, true
+	case *octypes.LocalizedText:
+		return localizedText{t}, true
+	case *octypes.IntDictionary:
+		// Plain maps are unmarshalled by the JSON codecs directly.
+		return (*map[string]int)(t), true
+	}
+	return nil, false
+}
+
+func wrapEncodeValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case octypes.NullString:
+		return nullString{&v}, true
+	case *octypes.NullString:
+		return nullString{v}, true
+	case octypes.NullInt64:
+		return nullInt64{&v}, true
+	case *octypes.NullInt64:
+		return nullInt64{v}, true
+	case octypes.NullFloat64:
+		return nullFloat64{&v}, true
+	case *octypes.NullFloat64:
+		return nullFloat64{v}, true
+	case octypes.NullBool:
+		return nullBool{&v}, true
+	case *octypes.NullBool:
+		return nullBool{v}, true
+	case octypes.CustomTime:
+		return customTime{&v}, true
+	case *octypes.CustomTime:
+		return customTime{v}, true
+	case octypes.NullBytes:
+		return nullBytes{&v}, true
+	case *octypes.NullBytes:
+		return nullBytes{v}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,191 @@
+// pgxutil_test.go
+package pgxutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coffyg/octypes"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+func TestRegisterIdempotent(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	n := len(m.TryWrapEncodePlanFuncs)
+	Register(m)
+	if len(m.TryWrapEncodePlanFuncs) != n {
+		t.Errorf("Expected %d wrap funcs after second Register, got %d", n, len(m.TryWrapEncodePlanFuncs))
+	}
+	dt, _ := m.TypeForName("int8")
+	if inner, ok := dt.Codec.(*codec); !ok {
+		t.Errorf("Expected int8 codec to be wrapped")
+	} else if _, ok := inner.Codec.(*codec); ok {
+		t.Errorf("Expected int8 codec to be wrapped once")
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	m := newMap()
+	now := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+
+	tests := []struct {
+		name  string
+		oid   uint32
+		value any
+		dst   any
+		check func(any) bool
+	}{
+		{"text", pgtype.TextOID, *octypes.NewNullString("hello"), &octypes.NullString{},
+			func(d any) bool { v := d.(*octypes.NullString); return v.Valid && v.String == "hello" }},
+		{"int8", pgtype.Int8OID, *octypes.NewNullInt64(42), &octypes.NullInt64{},
+			func(d any) bool { v := d.(*octypes.NullInt64); return v.Valid && v.Int64 == 42 }},
+		{"int4", pgtype.Int4OID, octypes.NewNullInt64(-7), &octypes.NullInt64{},
+			func(d any) bool { v := d.(*octypes.NullInt64); return v.Valid && v.Int64 == -7 }},
+		{"float8", pgtype.Float8OID, *octypes.NewNullFloat64(3.5), &octypes.NullFloat64{},
+			func(d any) bool { v := d.(*octypes.NullFloat64); return v.Valid && v.Float64 == 3.5 }},
+		{"numeric", pgtype.NumericOID, *octypes.NewNullFloat64(2.25), &octypes.NullFloat64{},
+			func(d any) bool { v := d.(*octypes.NullFloat64); return v.Valid && v.Float64 == 2.25 }},
+		{"bool", pgtype.BoolOID, *octypes.NewNullBool(true), &octypes.NullBool{},
+			func(d any) bool { v := d.(*octypes.NullBool); return v.Valid && v.Bool }},
+		{"timestamptz", pgtype.TimestamptzOID, *octypes.NewCustomTime(now), &octypes.CustomTime{},
+			func(d any) bool { v := d.(*octypes.CustomTime); return v.Valid && v.Time.Equal(now) }},
+		{"date", pgtype.DateOID, *octypes.NewCustomTime(now), &octypes.CustomTime{},
+			func(d any) bool {
+				v := d.(*octypes.CustomTime)
+				return v.Valid && v.Time.Equal(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC))
+			}},
+		{"bytea", pgtype.ByteaOID, *octypes.NewNullBytes([]byte{1, 2}), &octypes.NullBytes{},
+			func(d any) bool { v := d.(*octypes.NullBytes); return v.Valid && string(v.Bytes) == "\x01\x02" }},
+		{"jsonb", pgtype.JSONBOID, octypes.LocalizedText{"en": "Hi"}, &octypes.LocalizedText{},
+			func(d any) bool { return (*d.(*octypes.LocalizedText))["en"] == "Hi" }},
+	}
+
+	for _, tt := range tests {
+		buf, err := m.Encode(tt.oid, pgtype.BinaryFormatCode, tt.value, nil)
+		if err != nil {
+			t.Errorf("%s: error encoding: %v", tt.name, err)
+			continue
+		}
+		if err := m.Scan(tt.oid, pgtype.BinaryFormatCode, buf, tt.dst); err != nil {
+			t.Errorf("%s: error scanning: %v", tt.name, err)
+			continue
+		}
+		if !tt.check(tt.dst) {
+			t.Errorf("%s: unexpected scan result %+v", tt.name, tt.dst)
+		}
+	}
+}
+
+func TestEncodeNull(t *testing.T) {
+	m := newMap()
+	values := []any{
+		octypes.NullString{},
+		octypes.NullInt64{},
+		octypes.NullFloat64{},
+		octypes.NullBool{},
+		octypes.CustomTime{},
+		octypes.NullBytes{},
+		(*octypes.NullInt64)(nil),
+	}
+	for _, v := range values {
+		dt, ok := m.TypeForValue(v)
+		if !ok {
+			if _, isPtr := v.(*octypes.NullInt64); !isPtr {
+				t.Errorf("Expected default pg type for %T", v)
+				continue
+			}
+			dt, _ = m.TypeForName("int8")
+		}
+		buf, err := m.Encode(dt.OID, pgtype.BinaryFormatCode, v, nil)
+		if err != nil {
+			t.Errorf("Error encoding null %T: %v", v, err)
+		}
+		if buf != nil {
+			t.Errorf("Expected nil buffer for null %T, got %v", v, buf)
+		}
+	}
+}
+
+func TestScanNull(t *testing.T) {
+	m := newMap()
+	ni := octypes.NewNullInt64(5)
+	if err := m.Scan(pgtype.Int8OID, pgtype.BinaryFormatCode, nil, ni); err != nil {
+		t.Errorf("Error scanning null: %v", err)
+	}
+	if ni.Valid {
+		t.Errorf("Expected Valid false after scanning NULL")
+	}
+
+	ct := octypes.NewCustomTime(time.Now())
+	if err := m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, nil, ct); err != nil {
+		t.Errorf("Error scanning null: %v", err)
+	}
+	if ct.Valid {
+		t.Errorf("Expected Valid false after scanning NULL")
+	}
+}
+
+func TestScanTextFormat(t *testing.T) {
+	m := newMap()
+	ni := &octypes.NullInt64{}
+	if err := m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, []byte("123"), ni); err != nil {
+		t.Errorf("Error scanning text int8: %v", err)
+	}
+	if !ni.Valid || ni.Int64 != 123 {
+		t.Errorf("Expected 123, got Valid %v and Int64 %d", ni.Valid, ni.Int64)
+	}
+}
+
+func TestScanInfinity(t *testing.T) {
+	m := newMap()
+	ct := &octypes.CustomTime{}
+	if err := m.Scan(pgtype.TimestamptzOID, pgtype.TextFormatCode, []byte("infinity"), ct); err == nil {
+		t.Errorf("Expected error when scanning infinity, got nil")
+	}
+}
+
+func TestScanJSONText(t *testing.T) {
+	m := newMap()
+	id := &octypes.IntDictionary{}
+	if err := m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, []byte(`{"a":1}`), id); err != nil {
+		t.Errorf("Error scanning jsonb: %v", err)
+	}
+	if (*id)["a"] != 1 {
+		t.Errorf("Expected 1, got %d", (*id)["a"])
+	}
+
+	if err := m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, nil, id); err != nil {
+		t.Errorf("Error scanning null jsonb: %v", err)
+	}
+	if *id != nil {
+		t.Errorf("Expected nil IntDictionary after scanning NULL, got %v", *id)
+	}
+}
+
+func TestScanLocalizedTextSanitized(t *testing.T) {
+	octypes.SetLocalizedTextSanitizer(func(lang, value string) string { return value + "!" })
+	defer octypes.SetLocalizedTextSanitizer(nil)
+
+	m := newMap()
+	lt := &octypes.LocalizedText{}
+	if err := m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, []byte(`{"en":"Hi"}`), lt); err != nil {
+		t.Errorf("Error scanning jsonb: %v", err)
+	}
+	if (*lt)["en"] != "Hi!" {
+		t.Errorf("Expected sanitized value Hi!, got %q", (*lt)["en"])
+	}
+
+	if err := m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, nil, lt); err != nil {
+		t.Errorf("Error scanning null jsonb: %v", err)
+	}
+	if *lt != nil {
+		t.Errorf("Expected nil LocalizedText after scanning NULL, got %v", *lt)
+	}
+}
@@ -0,0 +1,196 @@
+// plural.go
+package octypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+)
+
+// PluralCategory is a CLDR plural category.
+type PluralCategory string
+
+// CLDR plural categories.
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// PluralRule returns the plural category of the count n, which is never
+// negative.
+type PluralRule func(n int64) PluralCategory
+
+// pluralRules maps primary languages to their cardinal rule. Languages not
+// listed use pluralRuleOneOther.
+var pluralRules = map[string]PluralRule{
+	"fr": pluralRuleFrench,
+	"pt": pluralRuleFrench,
+	"ja": pluralRuleOther,
+	"zh": pluralRuleOther,
+	"ko": pluralRuleOther,
+	"vi": pluralRuleOther,
+	"th": pluralRuleOther,
+	"id": pluralRuleOther,
+	"ms": pluralRuleOther,
+	"ru": pluralRuleEastSlavic,
+	"uk": pluralRuleEastSlavic,
+	"be": pluralRuleEastSlavic,
+	"pl": pluralRulePolish,
+	"cs": pluralRuleCzech,
+	"sk": pluralRuleCzech,
+	"ar": pluralRuleArabic,
+	"he": pluralRuleHebrew,
+}
+
+// RegisterPluralRule sets the plural rule used by PluralizedText.Select for
+// the primary language lang, e.g. "cy", replacing any built-in rule.
+func RegisterPluralRule(lang string, rule PluralRule) {
+	pluralRules[strings.ToLower(lang)] = rule
+}
+
+func pluralRuleOther(n int64) PluralCategory { return PluralOther }
+
+func pluralRuleOneOther(n int64) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralRuleFrench(n int64) PluralCategory {
+	if n <= 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralRuleEastSlavic(n int64) PluralCategory {
+	switch mod10, mod100 := n%10, n%100; {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralRulePolish(n int64) PluralCategory {
+	switch mod10, mod100 := n%10, n%100; {
+	case n == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralRuleCzech(n int64) PluralCategory {
+	switch {
+	case n == 1:
+		return PluralOne
+	case n >= 2 && n <= 4:
+		return PluralFew
+	}
+	return PluralOther
+}
+
+func pluralRuleArabic(n int64) PluralCategory {
+	switch mod100 := n % 100; {
+	case n == 0:
+		return PluralZero
+	case n == 1:
+		return PluralOne
+	case n == 2:
+		return PluralTwo
+	case mod100 >= 3 && mod100 <= 10:
+		return PluralFew
+	case mod100 >= 11:
+		return PluralMany
+	}
+	return PluralOther
+}
+
+func pluralRuleHebrew(n int64) PluralCategory {
+	switch n {
+	case 1:
+		return PluralOne
+	case 2:
+		return PluralTwo
+	}
+	return PluralOther
+}
+
+// PluralCategoryOf returns the plural category of n in the language lang.
+func PluralCategoryOf(lang string, n int64) PluralCategory {
+	if n < 0 {
+		n = -n
+	}
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if rule, ok := pluralRules[strings.ToLower(lang)]; ok {
+		return rule(n)
+	}
+	return pluralRuleOneOther(n)
+}
+
+// PluralizedText holds, per language, one string per CLDR plural category,
+// e.g. {"en": {"one": "%d item", "other": "%d items"}}.
+type PluralizedText map[string]map[PluralCategory]string
+
+// Select returns the string for count n in the best language for lang,
+// resolved with the fallback chain of LocalizedText.Resolve. The "other"
+// form is used when the language lacks the category of n. ok is false if
+// no language has a usable form.
+func (pt PluralizedText) Select(lang string, n int64) (string, bool) {
+	// Resolve walks the fallback chain over the language keys; languages
+	// without a usable form are removed and the chain is walked again.
+	langs := make(LocalizedText, len(pt))
+	for k := range pt {
+		langs[k] = k
+	}
+	for {
+		_, tag, ok := langs.Resolve(lang)
+		if !ok {
+			return "", false
+		}
+		forms := pt[tag]
+		if s := forms[PluralCategoryOf(tag, n)]; s != "" {
+			return s, true
+		}
+		if s := forms[PluralOther]; s != "" {
+			return s, true
+		}
+		delete(langs, tag)
+	}
+}
+
+// Scan implements the sql.Scanner interface.
+func (pt *PluralizedText) Scan(value interface{}) error {
+	if value == nil {
+		*pt = nil
+		return nil
+	}
+	asBytes, err := jsonScanSource(value)
+	if err != nil {
+		return err
+	}
+	var m map[string]map[PluralCategory]string
+	if err := json.Unmarshal(asBytes, &m); err != nil {
+		return err
+	}
+	*pt = m
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (pt PluralizedText) Value() (driver.Value, error) {
+	if pt == nil {
+		return nil, nil
+	}
+	return jsonValue(pt, map[string]map[PluralCategory]string(pt))
+}
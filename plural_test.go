@@ -0,0 +1,97 @@
+// plural_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPluralCategoryOf(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int64
+		want PluralCategory
+	}{
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"en-GB", -1, PluralOne},
+		{"fr", 0, PluralOne},
+		{"fr_CA", 2, PluralOther},
+		{"ja", 1, PluralOther},
+		{"ru", 21, PluralOne},
+		{"ru", 11, PluralMany},
+		{"ru", 23, PluralFew},
+		{"ru", 13, PluralMany},
+		{"pl", 1, PluralOne},
+		{"pl", 21, PluralMany},
+		{"pl", 22, PluralFew},
+		{"cs", 3, PluralFew},
+		{"cs", 5, PluralOther},
+		{"ar", 0, PluralZero},
+		{"ar", 2, PluralTwo},
+		{"ar", 105, PluralFew},
+		{"ar", 111, PluralMany},
+		{"ar", 100, PluralOther},
+	}
+	for _, tt := range tests {
+		if got := PluralCategoryOf(tt.lang, tt.n); got != tt.want {
+			t.Errorf("Expected %s for %s %d, got %s", tt.want, tt.lang, tt.n, got)
+		}
+	}
+}
+
+func TestPluralizedTextSelect(t *testing.T) {
+	var pt PluralizedText
+	err := json.Unmarshal([]byte(`{
+		"en": {"one": "%d item", "other": "%d items"},
+		"ru": {"one": "%d товар", "few": "%d товара", "many": "%d товаров"},
+		"de": {}
+	}`), &pt)
+	if err != nil {
+		t.Fatalf("Error unmarshalling PluralizedText: %v", err)
+	}
+
+	tests := []struct {
+		lang string
+		n    int64
+		want string
+	}{
+		{"en-US", 1, "%d item"},
+		{"en", 5, "%d items"},
+		{"ru", 3, "%d товара"},
+		{"ru", 5, "%d товаров"},
+		{"de", 2, "%d items"},
+	}
+	for _, tt := range tests {
+		if got, ok := pt.Select(tt.lang, tt.n); !ok || got != tt.want {
+			t.Errorf("Expected %q for %s %d, got %q", tt.want, tt.lang, tt.n, got)
+		}
+	}
+	if _, ok := (PluralizedText{"de": {}}).Select("de", 1); ok {
+		t.Errorf("Expected ok false without forms")
+	}
+
+	RegisterPluralRule("xx", func(n int64) PluralCategory { return PluralFew })
+	defer delete(pluralRules, "xx")
+	if got, _ := (PluralizedText{"xx": {"few": "f", "other": "o"}}).Select("xx", 1); got != "f" {
+		t.Errorf("Expected registered rule to apply, got %q", got)
+	}
+}
+
+func TestPluralizedTextScanValue(t *testing.T) {
+	pt := PluralizedText{"en": {"one": "item", "other": "items"}}
+	v, err := pt.Value()
+	if err != nil {
+		t.Fatalf("Error getting Value: %v", err)
+	}
+	var got PluralizedText
+	if err := got.Scan(v); err != nil || got["en"][PluralOther] != "items" {
+		t.Errorf("Expected round trip, got %v and error %v", got, err)
+	}
+	if err := got.Scan(nil); err != nil || got != nil {
+		t.Errorf("Expected nil after scanning NULL, got %v", got)
+	}
+	if v, _ := PluralizedText(nil).Value(); v != nil {
+		t.Errorf("Expected nil Value, got %v", v)
+	}
+}
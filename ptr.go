@@ -0,0 +1,129 @@
+// ptr.go
+package octypes
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Ptr returns a pointer to a copy of the string, or nil if ns is null.
+func (ns NullString) Ptr() *string {
+	if !ns.Valid {
+		return nil
+	}
+	s := ns.String
+	return &s
+}
+
+// NewNullStringFromPtr creates a NullString that is null if p is nil.
+// Unlike NewNullString, a pointer to "" gives a valid empty string.
+func NewNullStringFromPtr(p *string) *NullString {
+	if p == nil {
+		return &NullString{}
+	}
+	return &NullString{sql.NullString{String: *p, Valid: true}}
+}
+
+// Ptr returns a pointer to a copy of the integer, or nil if ni is null.
+func (ni NullInt64) Ptr() *int64 {
+	if !ni.Valid {
+		return nil
+	}
+	i := ni.Int64
+	return &i
+}
+
+// NewNullInt64FromPtr creates a NullInt64 that is null if p is nil.
+func NewNullInt64FromPtr(p *int64) *NullInt64 {
+	if p == nil {
+		return &NullInt64{}
+	}
+	return NewNullInt64(*p)
+}
+
+// Ptr returns a pointer to a copy of the boolean, or nil if nb is null.
+func (nb NullBool) Ptr() *bool {
+	if !nb.Valid {
+		return nil
+	}
+	b := nb.Bool
+	return &b
+}
+
+// NewNullBoolFromPtr creates a NullBool that is null if p is nil.
+func NewNullBoolFromPtr(p *bool) *NullBool {
+	if p == nil {
+		return &NullBool{}
+	}
+	return NewNullBool(*p)
+}
+
+// Ptr returns a pointer to a copy of the float, or nil if nf is null.
+func (nf NullFloat64) Ptr() *float64 {
+	if !nf.Valid {
+		return nil
+	}
+	f := nf.Float64
+	return &f
+}
+
+// NewNullFloat64FromPtr creates a NullFloat64 that is null if p is nil.
+func NewNullFloat64FromPtr(p *float64) *NullFloat64 {
+	if p == nil {
+		return &NullFloat64{}
+	}
+	return NewNullFloat64(*p)
+}
+
+// Ptr returns a pointer to a copy of the time, or nil if ct is null.
+func (ct CustomTime) Ptr() *time.Time {
+	if !ct.Valid {
+		return nil
+	}
+	t := ct.Time
+	return &t
+}
+
+// NewCustomTimeFromPtr creates a CustomTime that is null if p is nil.
+func NewCustomTimeFromPtr(p *time.Time) *CustomTime {
+	if p == nil {
+		return NewCustomTimeNull()
+	}
+	return NewCustomTime(*p)
+}
+
+// Ptr returns a pointer to the byte slice, or nil if nb is null. The slice
+// shares its backing array with nb.Bytes.
+func (nb NullBytes) Ptr() *[]byte {
+	if !nb.Valid {
+		return nil
+	}
+	b := nb.Bytes
+	return &b
+}
+
+// NewNullBytesFromPtr creates a NullBytes that is null if p is nil. A
+// pointer to a nil slice gives a valid empty value.
+func NewNullBytesFromPtr(p *[]byte) *NullBytes {
+	if p == nil {
+		return &NullBytes{}
+	}
+	return &NullBytes{Bytes: *p, Valid: true}
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if c is null.
+func (c Composite[T]) Ptr() *T {
+	if !c.Valid {
+		return nil
+	}
+	v := c.V
+	return &v
+}
+
+// NewCompositeFromPtr creates a Composite that is null if p is nil.
+func NewCompositeFromPtr[T any](p *T) *Composite[T] {
+	if p == nil {
+		return &Composite[T]{}
+	}
+	return NewComposite(*p)
+}
@@ -0,0 +1,69 @@
+// ptr_test.go
+package octypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPtr(t *testing.T) {
+	s := ""
+	if ns := NewNullStringFromPtr(&s); !ns.Valid || *ns.Ptr() != "" {
+		t.Errorf("Expected valid empty NullString, got %+v", ns)
+	}
+	if ns := NewNullStringFromPtr(nil); ns.Valid || ns.Ptr() != nil {
+		t.Errorf("Expected null NullString, got %+v", ns)
+	}
+
+	i := int64(42)
+	ni := NewNullInt64FromPtr(&i)
+	p := ni.Ptr()
+	*p = 7
+	if !ni.Valid || ni.Int64 != 42 {
+		t.Errorf("Expected Ptr to return a copy, got %+v", ni)
+	}
+	if NewNullInt64FromPtr(nil).Ptr() != nil {
+		t.Errorf("Expected nil pointer for null NullInt64")
+	}
+
+	b := false
+	if nb := NewNullBoolFromPtr(&b); !nb.Valid || *nb.Ptr() {
+		t.Errorf("Expected valid false NullBool, got %+v", nb)
+	}
+	if NewNullBoolFromPtr(nil).Ptr() != nil {
+		t.Errorf("Expected nil pointer for null NullBool")
+	}
+
+	f := 1.5
+	if nf := NewNullFloat64FromPtr(&f); *nf.Ptr() != 1.5 {
+		t.Errorf("Expected 1.5, got %+v", nf)
+	}
+	if NewNullFloat64FromPtr(nil).Ptr() != nil {
+		t.Errorf("Expected nil pointer for null NullFloat64")
+	}
+
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if ct := NewCustomTimeFromPtr(&tm); !ct.Ptr().Equal(tm) {
+		t.Errorf("Expected %v, got %+v", tm, ct)
+	}
+	if NewCustomTimeFromPtr(nil).Ptr() != nil {
+		t.Errorf("Expected nil pointer for null CustomTime")
+	}
+
+	var raw []byte
+	if nb := NewNullBytesFromPtr(&raw); !nb.Valid || nb.Ptr() == nil {
+		t.Errorf("Expected valid NullBytes, got %+v", nb)
+	}
+	if NewNullBytesFromPtr(nil).Ptr() != nil {
+		t.Errorf("Expected nil pointer for null NullBytes")
+	}
+
+	type point struct{ X, Y string }
+	pt := point{"1", "2"}
+	if c := NewCompositeFromPtr(&pt); *c.Ptr() != pt {
+		t.Errorf("Expected %v, got %+v", pt, c)
+	}
+	if NewCompositeFromPtr[point](nil).Ptr() != nil {
+		t.Errorf("Expected nil pointer for null Composite")
+	}
+}
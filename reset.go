@@ -0,0 +1,85 @@
+// reset.go
+package octypes
+
+// Reset sets ns to null.
+func (ns *NullString) Reset() {
+	*ns = NullString{}
+}
+
+// Reset sets ni to null.
+func (ni *NullInt64) Reset() {
+	*ni = NullInt64{}
+}
+
+// Reset sets nb to null.
+func (nb *NullBool) Reset() {
+	*nb = NullBool{}
+}
+
+// Reset sets nf to null.
+func (nf *NullFloat64) Reset() {
+	*nf = NullFloat64{}
+}
+
+// Reset sets ct to null.
+func (ct *CustomTime) Reset() {
+	*ct = CustomTime{}
+}
+
+// Reset sets nb to null and releases its bytes. The Encoding is kept, as it
+// describes the field rather than the value.
+func (nb *NullBytes) Reset() {
+	*nb = NullBytes{Encoding: nb.Encoding}
+}
+
+// Reset sets c to null and its value to the zero T.
+func (c *Composite[T]) Reset() {
+	*c = Composite[T]{}
+}
+
+// Reset sets lt to null and drops any token not parsed yet.
+func (lt *LazyTime) Reset() {
+	*lt = LazyTime{}
+}
+
+// The map and slice types are null when nil, so their Reset methods drop
+// the old map or slice instead of clearing it to keep its capacity: a
+// cleared map would be an empty value rather than null, copies of the value
+// share the map and would be emptied too, and Scan and UnmarshalJSON
+// replace the map rather than filling it, so its capacity would not be
+// reused anyway.
+
+// Reset sets lt to nil, which is null.
+func (lt *LocalizedText) Reset() {
+	*lt = nil
+}
+
+// Reset sets lh to nil, which is null.
+func (lh *LocalizedTextHstore) Reset() {
+	*lh = nil
+}
+
+// Reset sets id to nil, which is null.
+func (id *IntDictionary) Reset() {
+	*id = nil
+}
+
+// Reset sets pt to nil, which is null.
+func (pt *PluralizedText) Reset() {
+	*pt = nil
+}
+
+// Reset sets lc to nil, which is null.
+func (lc *LocalizedContent) Reset() {
+	*lc = nil
+}
+
+// Reset sets a to nil, which is null.
+func (a *NullStringArray) Reset() {
+	*a = nil
+}
+
+// Reset sets a to nil, which is null.
+func (a *NullInt64Array) Reset() {
+	*a = nil
+}
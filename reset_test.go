@@ -0,0 +1,46 @@
+// reset_test.go
+package octypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReset(t *testing.T) {
+	ns := NewNullString("a")
+	ns.Reset()
+	if ns.Valid || ns.String != "" {
+		t.Errorf("Expected zero NullString, got %+v", ns)
+	}
+
+	ct := NewCustomTime(time.Now())
+	ct.Reset()
+	if ct.Valid || !ct.Time.IsZero() {
+		t.Errorf("Expected zero CustomTime, got %+v", ct)
+	}
+
+	nb := NewNullBytesWithEncoding([]byte("a"), BytesEncodingHex)
+	nb.Reset()
+	if nb.Valid || nb.Bytes != nil || nb.Encoding != BytesEncodingHex {
+		t.Errorf("Expected null NullBytes keeping its encoding, got %+v", nb)
+	}
+
+	c := NewComposite(5)
+	c.Reset()
+	if c.Valid || c.V != 0 {
+		t.Errorf("Expected zero Composite, got %+v", c)
+	}
+
+	lt := LocalizedText{"en": "a"}
+	shared := lt
+	lt.Reset()
+	if lt != nil || shared["en"] != "a" {
+		t.Errorf("Expected nil LocalizedText and untouched shared map, got %v and %v", lt, shared)
+	}
+
+	arr := NullInt64Array{*NewNullInt64(1)}
+	arr.Reset()
+	if !arr.IsNull() {
+		t.Errorf("Expected null NullInt64Array, got %v", arr)
+	}
+}
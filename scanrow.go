@@ -0,0 +1,53 @@
+// scanrow.go
+package octypes
+
+import "fmt"
+
+// Rows is the subset of *sql.Rows used by ScanRow.
+type Rows interface {
+	Columns() ([]string, error)
+	Scan(dest ...interface{}) error
+}
+
+// ScanRow scans the current row into the struct dst points to, matching
+// columns to fields by their `db` tag. Every column must have a matching
+// field; fields without a matching column are left untouched. Scanned
+// fields with an `ocnull` tag then have their policy applied, as described
+// for Normalize.
+func ScanRow(rows Rows, dst interface{}) error {
+	sv, err := structPointerValue(dst)
+	if err != nil {
+		return err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fields := dbFields(sv.Type())
+	targets := make([]interface{}, len(columns))
+	var policies []dbField
+	for i, column := range columns {
+		for _, f := range fields {
+			if f.name == column {
+				targets[i] = sv.FieldByIndex(f.index).Addr().Interface()
+				if f.nullPolicy != "" {
+					policies = append(policies, f)
+				}
+				break
+			}
+		}
+		if targets[i] == nil {
+			return fmt.Errorf("no field with db tag %q in %s", column, sv.Type())
+		}
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return err
+	}
+	for _, f := range policies {
+		if err := normalizeField(sv.FieldByIndex(f.index), f.nullPolicy); err != nil {
+			return fmt.Errorf("column %q: %w", f.name, err)
+		}
+	}
+	return nil
+}
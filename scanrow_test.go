@@ -0,0 +1,110 @@
+// scanrow_test.go
+package octypes
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeRows is a single-row Rows implementation for tests.
+type fakeRows struct {
+	columns []string
+	values  []interface{}
+}
+
+func (r *fakeRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	if len(dest) != len(r.values) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r.values), len(dest))
+	}
+	for i, d := range dest {
+		switch d := d.(type) {
+		case sql.Scanner:
+			if err := d.Scan(r.values[i]); err != nil {
+				return err
+			}
+		case *string:
+			*d = r.values[i].(string)
+		case *int:
+			*d = int(r.values[i].(int64))
+		default:
+			return fmt.Errorf("unsupported destination %T", d)
+		}
+	}
+	return nil
+}
+
+type scanRowBase struct {
+	ID NullInt64 `db:"id"`
+}
+
+type scanRowUser struct {
+	scanRowBase
+	Name      NullString `db:"name"`
+	Email     string     `db:"email"`
+	CreatedAt CustomTime `db:"created_at"`
+	Score     NullFloat64
+	Skipped   NullBool `db:"-"`
+}
+
+func TestScanRow(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := &fakeRows{
+		columns: []string{"created_at", "id", "name", "email"},
+		values:  []interface{}{created, int64(7), nil, "a@example.com"},
+	}
+
+	var u scanRowUser
+	if err := ScanRow(rows, &u); err != nil {
+		t.Fatalf("Error scanning row: %v", err)
+	}
+	if !u.ID.Valid || u.ID.Int64 != 7 {
+		t.Errorf("Expected ID 7, got Valid %v and Int64 %d", u.ID.Valid, u.ID.Int64)
+	}
+	if u.Name.Valid {
+		t.Errorf("Expected Name to be null")
+	}
+	if u.Email != "a@example.com" {
+		t.Errorf("Expected Email 'a@example.com', got '%s'", u.Email)
+	}
+	if !u.CreatedAt.Valid || !u.CreatedAt.Time.Equal(created) {
+		t.Errorf("Expected CreatedAt %v, got %v", created, u.CreatedAt.Time)
+	}
+}
+
+func TestScanRowUnknownColumn(t *testing.T) {
+	rows := &fakeRows{columns: []string{"id", "missing"}, values: []interface{}{int64(1), "x"}}
+	var u scanRowUser
+	if err := ScanRow(rows, &u); err == nil {
+		t.Errorf("Expected error for column without matching field, got nil")
+	}
+}
+
+func TestScanRowInvalidDestination(t *testing.T) {
+	rows := &fakeRows{columns: []string{"id"}, values: []interface{}{int64(1)}}
+	var u scanRowUser
+	for _, dst := range []interface{}{u, (*scanRowUser)(nil), new(int)} {
+		if err := ScanRow(rows, dst); err == nil {
+			t.Errorf("Expected error for destination %T, got nil", dst)
+		}
+	}
+}
+
+func TestScanRowNullPolicy(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"bio", "nickname", "plain"},
+		values:  []interface{}{"", nil, ""},
+	}
+	var row argsPolicyRow
+	if err := ScanRow(rows, &row); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row.Bio.Valid || !row.Nickname.Valid || !row.Plain.Valid {
+		t.Errorf("Expected bio null, nickname and plain valid, got %+v", row)
+	}
+}
@@ -0,0 +1,42 @@
+// smallint.go
+package octypes
+
+import (
+	"math"
+	"strconv"
+)
+
+// Range of the pre-encoded integers. Small counts, ids and dictionary
+// values dominate real payloads.
+const (
+	smallIntMin = -99
+	smallIntMax = 999
+)
+
+// smallInts holds the decimal form of every integer from smallIntMin to
+// smallIntMax, indexed by the integer minus smallIntMin.
+var smallInts = func() (t [smallIntMax - smallIntMin + 1]string) {
+	for i := range t {
+		t[i] = strconv.Itoa(i + smallIntMin)
+	}
+	return t
+}()
+
+// appendInt appends the decimal form of i to dst, using the pre-encoded
+// table for small values.
+func appendInt(dst []byte, i int64) []byte {
+	if i >= smallIntMin && i <= smallIntMax {
+		return append(dst, smallInts[i-smallIntMin]...)
+	}
+	return strconv.AppendInt(dst, i, 10)
+}
+
+// smallIntFloat reports whether f is an integer in the pre-encoded range,
+// which JSON encodes exactly like the integer. Negative zero is excluded
+// since it encodes as -0.
+func smallIntFloat(f float64) (int64, bool) {
+	if f < smallIntMin || f > smallIntMax || f != math.Trunc(f) || (f == 0 && math.Signbit(f)) {
+		return 0, false
+	}
+	return int64(f), true
+}
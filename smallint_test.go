@@ -0,0 +1,46 @@
+// smallint_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestAppendInt(t *testing.T) {
+	for i := int64(smallIntMin - 5); i <= smallIntMax+5; i++ {
+		if got := string(appendInt(nil, i)); got != strconv.FormatInt(i, 10) {
+			t.Errorf("Expected %d, got %s", i, got)
+		}
+	}
+	for _, i := range []int64{math.MinInt64, math.MaxInt64, -1000, 100000} {
+		if got := string(appendInt([]byte("x"), i)); got != "x"+strconv.FormatInt(i, 10) {
+			t.Errorf("Expected x%d, got %s", i, got)
+		}
+	}
+}
+
+func TestNumberMarshalJSONMatchesEncodingJSON(t *testing.T) {
+	for _, i := range []int64{-100, -99, -1, 0, 7, 999, 1000, math.MaxInt64} {
+		got, _ := NewNullInt64(i).MarshalJSON()
+		want, _ := json.Marshal(i)
+		if string(got) != string(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+	for _, f := range []float64{-100, -99, -1, math.Copysign(0, -1), 0, 0.5, 42, 999, 999.5, 1000, 1e20, 1e21, -1.5e300, 1e-6, 1.2e-7, 5e-324, math.MaxFloat64, 0.1, 1.0 / 3} {
+		got, _ := NewNullFloat64(f).MarshalJSON()
+		want, _ := json.Marshal(f)
+		if string(got) != string(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func BenchmarkNullInt64MarshalJSON(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewNullInt64(int64(i % 1000)).MarshalJSON()
+	}
+}
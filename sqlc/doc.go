@@ -0,0 +1,50 @@
+// Package sqlc provides sqlc type overrides that make generated code use
+// octypes for nullable columns instead of sql.Null* or pgtype types.
+//
+// The overrides go in the gen.go section of sqlc.yaml (version 2). For
+// PostgreSQL:
+//
+//	sql:
+//	  - engine: postgresql
+//	    queries: query.sql
+//	    schema: schema.sql
+//	    gen:
+//	      go:
+//	        package: db
+//	        out: db
+//	        overrides:
+//	          - db_type: text
+//	            nullable: true
+//	            go_type:
+//	              import: github.com/coffyg/octypes
+//	              type: NullString
+//	          - db_type: pg_catalog.int8
+//	            nullable: true
+//	            go_type:
+//	              import: github.com/coffyg/octypes
+//	              type: NullInt64
+//	          # ...
+//
+// The full list is produced by WriteYAML, and `go run` of a small program
+// calling it is the easiest way to keep a configuration in sync:
+//
+//	sqlc.WriteYAML(os.Stdout, sqlc.PostgreSQL)
+//
+// sqlc matches db_type against its internal type names, which are
+// qualified with pg_catalog for most built-in PostgreSQL types.
+//
+// No adapter types are needed: every octypes type implements sql.Scanner
+// and driver.Valuer, which is all code generated for database/sql drivers
+// uses. With sql_package set to pgx/v5, register the native codecs from
+// the octypes/pgxutil package on each connection so binary results are
+// scanned directly.
+//
+// JSON columns are not overridden globally because their shape is
+// application specific; map them per column instead:
+//
+//	overrides:
+//	  - column: articles.title
+//	    go_type:
+//	      import: github.com/coffyg/octypes
+//	      type: LocalizedText
+package sqlc
@@ -0,0 +1,94 @@
+// overrides.go
+package sqlc
+
+import (
+	"fmt"
+	"io"
+)
+
+// ImportPath is the import path of the octypes package.
+const ImportPath = "github.com/coffyg/octypes"
+
+// Engine is a sqlc database engine.
+type Engine string
+
+// Supported engines.
+const (
+	PostgreSQL Engine = "postgresql"
+	MySQL      Engine = "mysql"
+)
+
+// Override maps a nullable database type to an octypes type.
+type Override struct {
+	DBType string
+	GoType string
+}
+
+var postgresOverrides = []Override{
+	{"text", "NullString"},
+	{"pg_catalog.varchar", "NullString"},
+	{"pg_catalog.bpchar", "NullString"},
+	{"citext", "NullString"},
+	{"pg_catalog.int2", "NullInt64"},
+	{"pg_catalog.int4", "NullInt64"},
+	{"pg_catalog.int8", "NullInt64"},
+	{"pg_catalog.float4", "NullFloat64"},
+	{"pg_catalog.float8", "NullFloat64"},
+	{"pg_catalog.numeric", "NullFloat64"},
+	{"pg_catalog.bool", "NullBool"},
+	{"date", "CustomTime"},
+	{"pg_catalog.timestamp", "CustomTime"},
+	{"pg_catalog.timestamptz", "CustomTime"},
+	{"bytea", "NullBytes"},
+}
+
+var mysqlOverrides = []Override{
+	{"char", "NullString"},
+	{"varchar", "NullString"},
+	{"tinytext", "NullString"},
+	{"text", "NullString"},
+	{"mediumtext", "NullString"},
+	{"longtext", "NullString"},
+	{"tinyint", "NullInt64"},
+	{"smallint", "NullInt64"},
+	{"mediumint", "NullInt64"},
+	{"int", "NullInt64"},
+	{"bigint", "NullInt64"},
+	{"float", "NullFloat64"},
+	{"double", "NullFloat64"},
+	{"decimal", "NullFloat64"},
+	{"date", "CustomTime"},
+	{"datetime", "CustomTime"},
+	{"timestamp", "CustomTime"},
+	{"binary", "NullBytes"},
+	{"varbinary", "NullBytes"},
+	{"blob", "NullBytes"},
+}
+
+// Overrides returns the nullable column overrides for engine, or nil for an
+// unknown engine. The returned slice must not be modified.
+func Overrides(engine Engine) []Override {
+	switch engine {
+	case PostgreSQL:
+		return postgresOverrides
+	case MySQL:
+		return mysqlOverrides
+	}
+	return nil
+}
+
+// WriteYAML writes the overrides for engine as a YAML sequence suitable for
+// the overrides key of a sqlc gen.go configuration.
+func WriteYAML(w io.Writer, engine Engine) error {
+	overrides := Overrides(engine)
+	if overrides == nil {
+		return fmt.Errorf("unknown sqlc engine %q", engine)
+	}
+	for _, o := range overrides {
+		_, err := fmt.Fprintf(w, "- db_type: %q\n  nullable: true\n  go_type:\n    import: %q\n    type: %q\n", o.DBType, ImportPath, o.GoType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// overrides_test.go
+package sqlc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coffyg/octypes"
+)
+
+// octypesByName lists every type the overrides may refer to.
+var octypesByName = map[string]interface{}{
+	"NullString":  octypes.NullString{},
+	"NullInt64":   octypes.NullInt64{},
+	"NullFloat64": octypes.NullFloat64{},
+	"NullBool":    octypes.NullBool{},
+	"CustomTime":  octypes.CustomTime{},
+	"NullBytes":   octypes.NullBytes{},
+}
+
+func TestOverridesReferToScannerTypes(t *testing.T) {
+	for _, engine := range []Engine{PostgreSQL, MySQL} {
+		for _, o := range Overrides(engine) {
+			v, ok := octypesByName[o.GoType]
+			if !ok {
+				t.Errorf("%s: override for %s refers to unknown type %s", engine, o.DBType, o.GoType)
+				continue
+			}
+			if _, ok := v.(driver.Valuer); !ok {
+				t.Errorf("%s does not implement driver.Valuer", o.GoType)
+			}
+			if _, ok := reflect.New(reflect.TypeOf(v)).Interface().(sql.Scanner); !ok {
+				t.Errorf("*%s does not implement sql.Scanner", o.GoType)
+			}
+		}
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	var b strings.Builder
+	if err := WriteYAML(&b, PostgreSQL); err != nil {
+		t.Fatalf("Error writing YAML: %v", err)
+	}
+	want := "- db_type: \"text\"\n  nullable: true\n  go_type:\n    import: \"github.com/coffyg/octypes\"\n    type: \"NullString\"\n"
+	if !strings.HasPrefix(b.String(), want) {
+		t.Errorf("Expected YAML to start with %q, got %q", want, b.String())
+	}
+	if n := strings.Count(b.String(), "- db_type:"); n != len(Overrides(PostgreSQL)) {
+		t.Errorf("Expected %d overrides, got %d", len(Overrides(PostgreSQL)), n)
+	}
+
+	if err := WriteYAML(&b, "sqlite"); err == nil {
+		t.Errorf("Expected error for unknown engine, got nil")
+	}
+}
@@ -0,0 +1,72 @@
+// timecache.go
+package octypes
+
+import (
+	"bytes"
+	"sync/atomic"
+	"time"
+)
+
+// timeCacheSize is the number of slots of the marshal cache. It must be a
+// power of two.
+const timeCacheSize = 256
+
+// timeCacheEntry is a rendered TimeResponse. Entries are immutable once
+// stored, and own their bytes so callers may modify what MarshalJSON
+// returns.
+type timeCacheEntry struct {
+	sec    int64
+	nsec   int
+	loc    *time.Location
+	locale string
+	style  TimeStyle
+	json   []byte
+}
+
+// timeCache is a direct-mapped cache of rendered TimeResponse objects: each
+// time maps to one slot, and a new time replaces the slot's previous one.
+var timeCache [timeCacheSize]atomic.Pointer[timeCacheEntry]
+
+// SetTimeMarshalCache makes CustomTime.MarshalJSON remember the JSON
+// objects of the last times it marshalled, keyed by instant and location,
+// so exports repeating the same timestamps render each one once. It only
+// applies to the default object format without relative output, which
+// depends on the current time. It is off by default; enabling it clears
+// the cache.
+func SetTimeMarshalCache(enabled bool) {
+	if enabled {
+		clearTimeCache()
+	}
+	timeMarshalCache = enabled
+}
+
+// clearTimeCache drops every cached entry, for settings that change the
+// rendered output without being part of the cache key.
+func clearTimeCache() {
+	for i := range timeCache {
+		timeCache[i].Store(nil)
+	}
+}
+
+// appendCachedTimeResponse is appendTimeResponse through the cache.
+func appendCachedTimeResponse(dst []byte, ct CustomTime, t time.Time) []byte {
+	// UnixNano is undefined outside about 1678 to 2262, so the key keeps
+	// the seconds and nanoseconds apart.
+	sec, nsec, loc := t.Unix(), t.Nanosecond(), t.Location()
+	h := (uint64(sec)*1e9 + uint64(nsec)) * 0x9e3779b97f4a7c15
+	slot := &timeCache[h>>56&(timeCacheSize-1)]
+	if e := slot.Load(); e != nil && e.sec == sec && e.nsec == nsec && e.loc == loc && e.locale == localizedTimeLocale && e.style == localizedTimeStyle {
+		return append(dst, e.json...)
+	}
+	start := len(dst)
+	dst = appendTimeResponse(dst, ct, t)
+	slot.Store(&timeCacheEntry{
+		sec:    sec,
+		nsec:   nsec,
+		loc:    loc,
+		locale: localizedTimeLocale,
+		style:  localizedTimeStyle,
+		json:   bytes.Clone(dst[start:]),
+	})
+	return dst
+}
@@ -0,0 +1,82 @@
+// timecache_test.go
+package octypes
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetTimeMarshalCache(t *testing.T) {
+	defer SetTimeMarshalCache(false)
+	SetTimeMarshalCache(true)
+
+	utc := time.Date(2023, 6, 15, 10, 20, 30, 0, time.UTC)
+	other := time.FixedZone("X", 3600)
+	for i := 0; i < 3; i++ {
+		for _, tm := range []time.Time{utc, utc.In(other), utc.Add(time.Nanosecond)} {
+			ct := *NewCustomTime(tm)
+			got, _ := ct.MarshalJSON()
+			want := string(appendTimeResponse(nil, ct, tm))
+			if string(got) != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+			// Callers own the returned bytes.
+			got[0] = 'X'
+		}
+	}
+
+	SetTimeLocalizedOutput("en-US", TimeStyleLong)
+	defer SetTimeLocalizedOutput("", TimeStyleMedium)
+	ct := *NewCustomTime(utc)
+	got, _ := ct.MarshalJSON()
+	if want := string(appendTimeResponse(nil, ct, utc)); string(got) != want {
+		t.Errorf("Expected the localized output to bypass stale entries, got %s", got)
+	}
+}
+
+func TestTimeMarshalCacheOutsideUnixNanoRange(t *testing.T) {
+	defer SetTimeMarshalCache(false)
+	SetTimeMarshalCache(true)
+
+	// 2^64 nanoseconds apart, so UnixNano wraps to the same value.
+	a := time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := a.Add(math.MaxInt64).Add(math.MaxInt64).Add(2)
+	if a.UnixNano() != b.UnixNano() {
+		t.Fatalf("Expected equal UnixNano for %v and %v", a, b)
+	}
+	for _, tm := range []time.Time{a, b} {
+		ct := *NewCustomTime(tm)
+		got, _ := ct.MarshalJSON()
+		if want := string(appendTimeResponse(nil, ct, tm)); string(got) != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestSetTimeFormatterClearsTimeMarshalCache(t *testing.T) {
+	defer SetTimeMarshalCache(false)
+	SetTimeMarshalCache(true)
+	SetTimeLocalizedOutput("en-US", TimeStyleLong)
+	defer SetTimeLocalizedOutput("", TimeStyleMedium)
+	defer SetTimeFormatter(nil)
+
+	ct := *NewCustomTime(time.Date(2023, 6, 15, 10, 20, 30, 0, time.UTC))
+	ct.MarshalJSON()
+	SetTimeFormatter(upperTimeFormatter{})
+	got, _ := ct.MarshalJSON()
+	if !strings.Contains(string(got), "en-US:JUN 15") {
+		t.Errorf("Expected output of the new formatter, got %s", got)
+	}
+}
+
+func BenchmarkCustomTimeMarshalJSONCache(b *testing.B) {
+	defer SetTimeMarshalCache(false)
+	SetTimeMarshalCache(true)
+	ct := *NewCustomTime(time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ct.MarshalJSON()
+	}
+}
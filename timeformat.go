@@ -0,0 +1,41 @@
+// timeformat.go
+package octypes
+
+import "time"
+
+// TimeJSONFormat selects the JSON representation produced by
+// CustomTime.MarshalJSON. UnmarshalJSON accepts all of them regardless of
+// the setting.
+type TimeJSONFormat uint8
+
+const (
+	// TimeFormatObject marshals a TimeResponse object. This is the default.
+	TimeFormatObject TimeJSONFormat = iota
+	// TimeFormatRFC3339 marshals an RFC 3339 string with nanosecond
+	// precision, e.g. "2023-06-15T10:20:30.5Z".
+	TimeFormatRFC3339
+	// TimeFormatUnixMS marshals the number of milliseconds since the Unix
+	// epoch.
+	TimeFormatUnixMS
+)
+
+// TimeMarshaler encodes the JSON of a valid CustomTime, for applications
+// with an established time envelope. Install one with SetTimeMarshaler.
+type TimeMarshaler interface {
+	MarshalTime(t time.Time) ([]byte, error)
+}
+
+// TimeUnmarshaler may be implemented by a TimeMarshaler to decode its own
+// output. CustomTime.UnmarshalJSON tries it first and falls back to the
+// built-in formats when it returns an error.
+type TimeUnmarshaler interface {
+	UnmarshalTime(b []byte) (time.Time, error)
+}
+
+// TimeMarshalerFunc adapts a function to the TimeMarshaler interface.
+type TimeMarshalerFunc func(t time.Time) ([]byte, error)
+
+// MarshalTime calls f(t).
+func (f TimeMarshalerFunc) MarshalTime(t time.Time) ([]byte, error) {
+	return f(t)
+}
@@ -0,0 +1,122 @@
+// timeformat_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetTimeJSONFormat(t *testing.T) {
+	defer SetTimeJSONFormat(TimeFormatObject)
+	tm := time.Date(2023, 6, 15, 10, 20, 30, 500000000, time.UTC)
+
+	tests := []struct {
+		format TimeJSONFormat
+		want   string
+	}{
+		{TimeFormatRFC3339, `{"t":"2023-06-15T10:20:30.5Z","n":null}`},
+		{TimeFormatUnixMS, `{"t":1686824430500,"n":null}`},
+	}
+	for _, tt := range tests {
+		SetTimeJSONFormat(tt.format)
+		v := struct {
+			T CustomTime `json:"t"`
+			N CustomTime `json:"n"`
+		}{T: *NewCustomTime(tm)}
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("Error marshalling with format %d: %v", tt.format, err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, b)
+		}
+
+		if err := json.Unmarshal(b, &v); err != nil {
+			t.Errorf("Error unmarshalling %s: %v", b, err)
+		}
+		if !v.T.Valid || !v.T.Time.Equal(tm) || v.N.Valid {
+			t.Errorf("Expected %v and null after round trip, got %v and %v", tm, v.T.Time, v.N.Valid)
+		}
+	}
+
+	SetTimeJSONFormat(TimeFormatObject)
+	b, _ := NewCustomTime(tm).MarshalJSON()
+	var tr TimeResponse
+	if err := json.Unmarshal(b, &tr); err != nil || tr.UnixMS != 1686824430500 {
+		t.Errorf("Expected TimeResponse object, got %s", b)
+	}
+}
+
+type envelopeTimeMarshaler struct{}
+
+func (envelopeTimeMarshaler) MarshalTime(t time.Time) ([]byte, error) {
+	return json.Marshal(map[string]int64{"epoch": t.Unix()})
+}
+
+func (envelopeTimeMarshaler) UnmarshalTime(b []byte) (time.Time, error) {
+	var v struct {
+		Epoch *int64 `json:"epoch"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil || v.Epoch == nil {
+		return time.Time{}, errors.New("not an envelope")
+	}
+	return time.Unix(*v.Epoch, 0), nil
+}
+
+func TestSetTimeMarshaler(t *testing.T) {
+	SetTimeMarshaler(envelopeTimeMarshaler{})
+	defer SetTimeMarshaler(nil)
+	tm := time.Unix(1686824430, 0)
+
+	b, err := json.Marshal(NewCustomTime(tm))
+	if err != nil || string(b) != `{"epoch":1686824430}` {
+		t.Errorf("Expected envelope, got %s and error %v", b, err)
+	}
+	var ct CustomTime
+	if err := json.Unmarshal(b, &ct); err != nil || !ct.Time.Equal(tm) {
+		t.Errorf("Expected %v, got %v and error %v", tm, ct.Time, err)
+	}
+	if err := json.Unmarshal([]byte(`"2023-06-15"`), &ct); err != nil || ct.Time.Day() != 15 {
+		t.Errorf("Expected fallback to built-in formats, got %v and error %v", ct.Time, err)
+	}
+	if b, _ := json.Marshal(CustomTime{}); string(b) != "null" {
+		t.Errorf("Expected null, got %s", b)
+	}
+
+	SetTimeMarshaler(TimeMarshalerFunc(func(t time.Time) ([]byte, error) {
+		return []byte(`"custom"`), nil
+	}))
+	if b, _ := json.Marshal(NewCustomTime(tm)); string(b) != `"custom"` {
+		t.Errorf("Expected %q, got %s", "custom", b)
+	}
+}
+
+func TestSetTimeMarshalPrecision(t *testing.T) {
+	defer SetTimeMarshalPrecision(0)
+	tm := time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC)
+
+	tests := []struct {
+		precision time.Duration
+		iso       string
+		us        int64
+		full      int64
+	}{
+		{0, "2023-06-15T10:20:30.123456789Z", 123456789, 1686824430123456},
+		{time.Microsecond, "2023-06-15T10:20:30.123456Z", 123456000, 1686824430123456},
+		{time.Millisecond, "2023-06-15T10:20:30.123Z", 123000000, 1686824430123000},
+		{time.Second, "2023-06-15T10:20:30Z", 0, 1686824430000000},
+	}
+	for _, tt := range tests {
+		SetTimeMarshalPrecision(tt.precision)
+		b, _ := json.Marshal(NewCustomTime(tm))
+		var tr TimeResponse
+		if err := json.Unmarshal(b, &tr); err != nil {
+			t.Errorf("Error unmarshalling %s: %v", b, err)
+		}
+		if tr.ISO != tt.iso || tr.US != tt.us || tr.Full != tt.full {
+			t.Errorf("Precision %v: expected %s, %d, %d, got %s", tt.precision, tt.iso, tt.us, tt.full, b)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// timelocale.go
+package octypes
+
+import "time"
+
+// TimeStyle selects the length of a localized date.
+type TimeStyle uint8
+
+const (
+	// TimeStyleShort is a numeric date, e.g. "6/15/23".
+	TimeStyleShort TimeStyle = iota
+	// TimeStyleMedium abbreviates the month, e.g. "Jun 15, 2023".
+	TimeStyleMedium
+	// TimeStyleLong spells out the month, e.g. "June 15, 2023".
+	TimeStyleLong
+	// TimeStyleFull adds the weekday, e.g. "Thursday, June 15, 2023".
+	TimeStyleFull
+)
+
+// TimeFormatter produces display-ready dates for a BCP 47 locale. Install
+// one backed by golang.org/x/text or ICU data with SetTimeFormatter.
+type TimeFormatter interface {
+	FormatTime(t time.Time, locale string, style TimeStyle) string
+}
+
+// englishTimeFormatter is the default TimeFormatter. It formats every
+// locale in US English.
+type englishTimeFormatter struct{}
+
+var englishTimeLayouts = [...]string{
+	TimeStyleShort:  "1/2/06",
+	TimeStyleMedium: "Jan 2, 2006",
+	TimeStyleLong:   "January 2, 2006",
+	TimeStyleFull:   "Monday, January 2, 2006",
+}
+
+func (englishTimeFormatter) FormatTime(t time.Time, _ string, style TimeStyle) string {
+	if int(style) >= len(englishTimeLayouts) {
+		style = TimeStyleMedium
+	}
+	return t.Format(englishTimeLayouts[style])
+}
+
+// Format returns ct as a localized date using the configured TimeFormatter.
+// A null CustomTime gives an empty string.
+func (ct CustomTime) Format(locale string, style TimeStyle) string {
+	if !ct.Valid {
+		return ""
+	}
+	return timeFormatter.FormatTime(ct.Time, locale, style)
+}
@@ -0,0 +1,58 @@
+// timelocale_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type upperTimeFormatter struct{}
+
+func (upperTimeFormatter) FormatTime(t time.Time, locale string, style TimeStyle) string {
+	return locale + ":" + strings.ToUpper(t.Format("Jan 2"))
+}
+
+func TestCustomTimeFormat(t *testing.T) {
+	ct := NewCustomTime(time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC))
+	tests := []struct {
+		style TimeStyle
+		want  string
+	}{
+		{TimeStyleShort, "6/15/23"},
+		{TimeStyleMedium, "Jun 15, 2023"},
+		{TimeStyleLong, "June 15, 2023"},
+		{TimeStyleFull, "Thursday, June 15, 2023"},
+	}
+	for _, tt := range tests {
+		if got := ct.Format("en-US", tt.style); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+	if got := (CustomTime{}).Format("en-US", TimeStyleLong); got != "" {
+		t.Errorf("Expected empty string for null, got %q", got)
+	}
+
+	SetTimeFormatter(upperTimeFormatter{})
+	defer SetTimeFormatter(nil)
+	if got := ct.Format("fr-FR", TimeStyleLong); got != "fr-FR:JUN 15" {
+		t.Errorf("Expected custom formatter output, got %q", got)
+	}
+}
+
+func TestTimeLocalizedOutput(t *testing.T) {
+	ct := NewCustomTime(time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC))
+	b, _ := json.Marshal(ct)
+	if strings.Contains(string(b), "localized") {
+		t.Errorf("Expected no localized field by default, got %s", b)
+	}
+
+	SetTimeLocalizedOutput("en-US", TimeStyleLong)
+	defer SetTimeLocalizedOutput("", TimeStyleMedium)
+	b, _ = json.Marshal(ct)
+	var tr TimeResponse
+	if err := json.Unmarshal(b, &tr); err != nil || tr.Localized != "June 15, 2023" {
+		t.Errorf("Expected localized %q, got %s", "June 15, 2023", b)
+	}
+}
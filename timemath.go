@@ -0,0 +1,122 @@
+// timemath.go
+package octypes
+
+import "time"
+
+// Add returns ct shifted by d. A null CustomTime stays null.
+func (ct CustomTime) Add(d time.Duration) CustomTime {
+	if !ct.Valid {
+		return CustomTime{}
+	}
+	return *NewCustomTime(ct.Time.Add(d))
+}
+
+// Sub returns the duration ct-other. ok is false if either value is null.
+func (ct CustomTime) Sub(other CustomTime) (d time.Duration, ok bool) {
+	if !ct.Valid || !other.Valid {
+		return 0, false
+	}
+	return ct.Time.Sub(other.Time), true
+}
+
+// Truncate returns ct rounded down to a multiple of d since the zero time,
+// as time.Time.Truncate does. A null CustomTime stays null.
+func (ct CustomTime) Truncate(d time.Duration) CustomTime {
+	if !ct.Valid {
+		return CustomTime{}
+	}
+	return *NewCustomTime(ct.Time.Truncate(d))
+}
+
+// StartOfDay returns midnight of ct's day in loc. A nil loc uses ct's own
+// location. A null CustomTime stays null.
+func (ct CustomTime) StartOfDay(loc *time.Location) CustomTime {
+	if !ct.Valid {
+		return CustomTime{}
+	}
+	t := ct.Time.In(orLocation(loc, ct.Time))
+	return *NewCustomTime(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+}
+
+// EndOfMonth returns the last nanosecond of ct's month in loc. A nil loc
+// uses ct's own location. A null CustomTime stays null.
+func (ct CustomTime) EndOfMonth(loc *time.Location) CustomTime {
+	if !ct.Valid {
+		return CustomTime{}
+	}
+	t := ct.Time.In(orLocation(loc, ct.Time))
+	first := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	return *NewCustomTime(first.Add(-time.Nanosecond))
+}
+
+// AddDays returns ct moved by n calendar days in loc, keeping the wall
+// clock time, so a day across a DST transition lasts 23 or 25 hours. A nil
+// loc uses ct's own location. A null CustomTime stays null.
+func (ct CustomTime) AddDays(n int, loc *time.Location) CustomTime {
+	if !ct.Valid {
+		return CustomTime{}
+	}
+	t := ct.Time.In(orLocation(loc, ct.Time))
+	return *NewCustomTime(time.Date(t.Year(), t.Month(), t.Day()+n,
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()))
+}
+
+// AddMonths returns ct moved by n calendar months in loc, keeping the wall
+// clock time. Days past the end of the target month are clamped to its last
+// day, so January 31 plus one month is February 28 or 29. A nil loc uses
+// ct's own location. A null CustomTime stays null.
+func (ct CustomTime) AddMonths(n int, loc *time.Location) CustomTime {
+	if !ct.Valid {
+		return CustomTime{}
+	}
+	t := ct.Time.In(orLocation(loc, ct.Time))
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	day := t.Day()
+	if last := daysIn(first.Year(), first.Month()); day > last {
+		day = last
+	}
+	return *NewCustomTime(time.Date(first.Year(), first.Month(), day,
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()))
+}
+
+// daysIn returns the number of days in month of year.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// orLocation returns loc, or t's location if loc is nil.
+func orLocation(loc *time.Location, t time.Time) *time.Location {
+	if loc == nil {
+		return t.Location()
+	}
+	return loc
+}
+
+// ISOWeek returns the ISO 8601 week number of ct, from 1 to 53. The
+// week-numbering year may differ from ct's calendar year around New Year;
+// use Time.ISOWeek when it matters. A null CustomTime gives a null result.
+func (ct CustomTime) ISOWeek() NullInt64 {
+	if !ct.Valid {
+		return NullInt64{}
+	}
+	_, week := ct.Time.ISOWeek()
+	return *NewNullInt64(int64(week))
+}
+
+// Quarter returns the calendar quarter of ct, from 1 to 4. A null
+// CustomTime gives a null result.
+func (ct CustomTime) Quarter() NullInt64 {
+	if !ct.Valid {
+		return NullInt64{}
+	}
+	return *NewNullInt64(int64(ct.Time.Month()+2) / 3)
+}
+
+// DayOfYear returns the day of the year of ct, from 1 to 366. A null
+// CustomTime gives a null result.
+func (ct CustomTime) DayOfYear() NullInt64 {
+	if !ct.Valid {
+		return NullInt64{}
+	}
+	return *NewNullInt64(int64(ct.Time.YearDay()))
+}
@@ -0,0 +1,120 @@
+// timemath_test.go
+package octypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomTimeArithmetic(t *testing.T) {
+	tm := time.Date(2024, 2, 10, 15, 30, 45, 500, time.UTC)
+	ct := *NewCustomTime(tm)
+	null := CustomTime{}
+
+	if got := ct.Add(time.Hour); !got.Valid || !got.Time.Equal(tm.Add(time.Hour)) {
+		t.Errorf("Expected %v, got %v", tm.Add(time.Hour), got.Time)
+	}
+	if d, ok := ct.Add(time.Minute).Sub(ct); !ok || d != time.Minute {
+		t.Errorf("Expected 1m and ok, got %v and %v", d, ok)
+	}
+	if _, ok := ct.Sub(null); ok {
+		t.Errorf("Expected ok false when subtracting null")
+	}
+	if got := ct.Truncate(time.Hour); !got.Time.Equal(time.Date(2024, 2, 10, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 15:00, got %v", got.Time)
+	}
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	want := time.Date(2024, 2, 11, 0, 0, 0, 0, tokyo)
+	if got := ct.StartOfDay(tokyo); !got.Time.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got.Time)
+	}
+	if got := ct.StartOfDay(nil); !got.Time.Equal(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected midnight UTC, got %v", got.Time)
+	}
+
+	want = time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC)
+	if got := ct.EndOfMonth(nil); !got.Time.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got.Time)
+	}
+
+	for name, got := range map[string]CustomTime{
+		"Add":        null.Add(time.Hour),
+		"Truncate":   null.Truncate(time.Hour),
+		"StartOfDay": null.StartOfDay(nil),
+		"EndOfMonth": null.EndOfMonth(tokyo),
+	} {
+		if got.Valid {
+			t.Errorf("Expected %s of null to be null", name)
+		}
+	}
+}
+
+func TestCustomTimeCalendarAccessors(t *testing.T) {
+	tests := []struct {
+		tm                     time.Time
+		week, quarter, yearDay int64
+	}{
+		{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 53, 1, 1},
+		{time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), 20, 2, 136},
+		{time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 1, 4, 366},
+	}
+	for _, tt := range tests {
+		ct := NewCustomTime(tt.tm)
+		if got := ct.ISOWeek(); !got.Valid || got.Int64 != tt.week {
+			t.Errorf("Expected week %d for %v, got %v", tt.week, tt.tm, got.Int64)
+		}
+		if got := ct.Quarter(); !got.Valid || got.Int64 != tt.quarter {
+			t.Errorf("Expected quarter %d for %v, got %v", tt.quarter, tt.tm, got.Int64)
+		}
+		if got := ct.DayOfYear(); !got.Valid || got.Int64 != tt.yearDay {
+			t.Errorf("Expected day %d for %v, got %v", tt.yearDay, tt.tm, got.Int64)
+		}
+	}
+
+	null := CustomTime{}
+	if null.ISOWeek().Valid || null.Quarter().Valid || null.DayOfYear().Valid {
+		t.Errorf("Expected null accessors for null CustomTime")
+	}
+}
+
+func TestCustomTimeCalendarMath(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Europe/Paris not available: %v", err)
+	}
+	// DST starts in Paris on 2024-03-31 at 02:00.
+	ct := *NewCustomTime(time.Date(2024, 3, 30, 12, 0, 0, 0, paris))
+	got := ct.AddDays(1, nil)
+	if want := time.Date(2024, 3, 31, 12, 0, 0, 0, paris); !got.Time.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got.Time)
+	}
+	if d, _ := got.Sub(ct); d != 23*time.Hour {
+		t.Errorf("Expected a 23h day, got %v", d)
+	}
+	utc := *NewCustomTime(time.Date(2024, 3, 30, 11, 0, 0, 0, time.UTC))
+	if got := utc.AddDays(1, paris); !got.Time.Equal(time.Date(2024, 3, 31, 12, 0, 0, 0, paris)) {
+		t.Errorf("Expected noon in Paris, got %v", got.Time)
+	}
+
+	tests := []struct {
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC), 1, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC), 1, time.Date(2023, 2, 28, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC), -1, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 11, 15, 9, 0, 0, 0, time.UTC), 14, time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := NewCustomTime(tt.from).AddMonths(tt.n, nil); !got.Time.Equal(tt.want) {
+			t.Errorf("Expected %v for %v + %d months, got %v", tt.want, tt.from, tt.n, got.Time)
+		}
+	}
+
+	null := CustomTime{}
+	if null.AddDays(1, nil).Valid || null.AddMonths(1, paris).Valid {
+		t.Errorf("Expected null to stay null")
+	}
+}
@@ -0,0 +1,267 @@
+// timeparse.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLiteTimeStorage describes how an application stores times in SQLite,
+// which has no dedicated time type.
+type SQLiteTimeStorage uint8
+
+const (
+	// SQLiteTimeISO8601 stores times as ISO8601 text. Integer and REAL
+	// sources are rejected. This is the default.
+	SQLiteTimeISO8601 SQLiteTimeStorage = iota
+	// SQLiteTimeUnixSeconds stores times as seconds since the Unix epoch.
+	SQLiteTimeUnixSeconds
+	// SQLiteTimeUnixMillis stores times as milliseconds since the Unix epoch.
+	SQLiteTimeUnixMillis
+	// SQLiteTimeJulianDay stores times as fractional Julian day numbers, as
+	// produced by SQLite's julianday().
+	SQLiteTimeJulianDay
+)
+
+// ErrTimeOutOfRange is returned by CustomTime.Scan and UnmarshalJSON for
+// times whose year falls outside the range set with SetTimeYearRange.
+var ErrTimeOutOfRange = errors.New("time out of range")
+
+// checkYearRange resets ct to null and returns ErrTimeOutOfRange if ct is
+// valid but outside the configured year range.
+func (ct *CustomTime) checkYearRange() error {
+	if !ct.Valid || (minTimeYear == 0 && maxTimeYear == 0) {
+		return nil
+	}
+	if y := ct.Time.Year(); y < minTimeYear || y > maxTimeYear {
+		*ct = CustomTime{}
+		return fmt.Errorf("%w: year %d is not within [%d, %d]", ErrTimeOutOfRange, y, minTimeYear, maxTimeYear)
+	}
+	return nil
+}
+
+// timeResponseFields decodes a possibly partial TimeResponse object.
+// Pointers tell absent fields from zero ones, and Full is raw because it is
+// emitted as a string but clients may send a number.
+type timeResponseFields struct {
+	ISO    string          `json:"iso"`
+	TZ     string          `json:"tz"`
+	Unix   *int64          `json:"unix"`
+	UnixMS *int64          `json:"unixms"`
+	US     *int64          `json:"us"`
+	Full   json.RawMessage `json:"full"`
+}
+
+// time returns the instant described by the first usable field, in order
+// iso, full, unixms, unix. unix is combined with us, the nanoseconds within
+// the second, when present. ok is false if no field is set.
+func (tr timeResponseFields) time() (t time.Time, ok bool, err error) {
+	switch {
+	case tr.ISO != "":
+		t, err = time.Parse(time.RFC3339Nano, tr.ISO)
+		return t, err == nil, err
+	case len(tr.Full) > 0 && string(tr.Full) != "null":
+		full, err := strconv.ParseInt(strings.Trim(string(tr.Full), `"`), 10, 64)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid full time %s", tr.Full)
+		}
+		return time.UnixMicro(full), true, nil
+	case tr.UnixMS != nil:
+		return time.UnixMilli(*tr.UnixMS), true, nil
+	case tr.Unix != nil:
+		var ns int64
+		if tr.US != nil {
+			ns = *tr.US
+		}
+		return time.Unix(*tr.Unix, ns), true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+// UnixTimestampPolicy selects how bare Unix timestamps are interpreted.
+type UnixTimestampPolicy uint8
+
+const (
+	// UnixPolicyMillis treats timestamps as milliseconds. This is the
+	// default.
+	UnixPolicyMillis UnixTimestampPolicy = iota
+	// UnixPolicySeconds treats timestamps as seconds.
+	UnixPolicySeconds
+	// UnixPolicyAuto treats timestamps with an absolute value below
+	// unixAutoThreshold as seconds and larger ones as milliseconds. Seconds
+	// are thereby recognized up to year 5138, and milliseconds from
+	// March 1973 onwards.
+	UnixPolicyAuto
+)
+
+// unixAutoThreshold separates seconds from milliseconds for UnixPolicyAuto.
+const unixAutoThreshold = 1e11
+
+// unixIsSeconds reports whether the timestamp v is in seconds under the
+// configured policy.
+func unixIsSeconds(v float64) bool {
+	switch unixTimestampPolicy {
+	case UnixPolicySeconds:
+		return true
+	case UnixPolicyAuto:
+		return math.Abs(v) < unixAutoThreshold
+	}
+	return false
+}
+
+// unixIntTime converts an integer Unix timestamp according to the policy.
+func unixIntTime(n int64) time.Time {
+	if unixIsSeconds(float64(n)) {
+		return time.Unix(n, 0)
+	}
+	return time.UnixMilli(n)
+}
+
+// unixFloatTime converts a float Unix timestamp according to the policy.
+// Fractional seconds are kept; fractional milliseconds are truncated.
+func unixFloatTime(f float64) time.Time {
+	if unixIsSeconds(f) {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9))
+	}
+	return time.UnixMilli(int64(f))
+}
+
+// julianDayUnixEpoch is the Julian day number of 1970-01-01T00:00:00Z.
+const julianDayUnixEpoch = 2440587.5
+
+// builtinTimeLayouts are tried in order by parseTimeText.
+var builtinTimeLayouts = []string{
+	time.RFC3339Nano,
+	// Postgres timestamptz and timestamp text output. The layout without an
+	// offset also covers MySQL DATETIME and TIMESTAMP.
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// httpTimeLayouts are the formats allowed for HTTP dates such as
+// Last-Modified, plus RFC1123Z. They are tried by both parseTimeText and
+// parseJSONTimeString.
+var httpTimeLayouts = []string{
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// parseTimeText parses a textual time as returned by database drivers. Unix
+// integer strings are interpreted according to the UnixTimestampPolicy.
+func parseTimeText(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if isUnixIntegerText(s) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err == nil {
+			return unixIntTime(n), nil
+		}
+	}
+	for _, layout := range builtinTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	if t, ok := parseTimeLayouts(s, httpTimeLayouts); ok {
+		return t, nil
+	}
+	if t, ok := parseTimeLayouts(s, extraTimeLayouts); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as time", s)
+}
+
+// parseJSONTimeString parses a JSON string time: a date, an RFC 3339 time as
+// written with TimeFormatRFC3339, an HTTP date, or a registered layout.
+func parseJSONTimeString(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err == nil {
+		return t, nil
+	}
+	if t, rerr := time.Parse(time.RFC3339Nano, s); rerr == nil {
+		return t, nil
+	}
+	if t, ok := parseTimeLayouts(s, httpTimeLayouts); ok {
+		return t, nil
+	}
+	if t, ok := parseTimeLayouts(s, extraTimeLayouts); ok {
+		return t, nil
+	}
+	return time.Time{}, err
+}
+
+// parseTimeLayouts tries layouts in order.
+func parseTimeLayouts(s string, layouts []string) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isUnixIntegerText reports whether s is an optionally signed run of digits.
+func isUnixIntegerText(s string) bool {
+	if strings.HasPrefix(s, "-") {
+		s = s[1:]
+	}
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isMySQLZeroDate reports whether s is a MySQL zero date or datetime, e.g.
+// "0000-00-00" or "0000-00-00 00:00:00.000000".
+func isMySQLZeroDate(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "0000-00-00") && strings.Trim(s, "0-:. ") == ""
+}
+
+// scanNumber interprets a numeric Scan source according to the configured
+// SQLite time storage. isInt reports whether the source was an integer, in
+// which case i holds its exact value.
+func (ct *CustomTime) scanNumber(f float64, i int64, isInt bool) error {
+	var t time.Time
+	switch sqliteTimeStorage {
+	case SQLiteTimeUnixSeconds:
+		if isInt {
+			t = time.Unix(i, 0)
+		} else {
+			sec, frac := math.Modf(f)
+			t = time.Unix(int64(sec), int64(frac*1e9))
+		}
+	case SQLiteTimeUnixMillis:
+		if isInt {
+			t = time.UnixMilli(i)
+		} else {
+			t = time.Unix(0, int64(f*float64(time.Millisecond)))
+		}
+	case SQLiteTimeJulianDay:
+		sec := (f - julianDayUnixEpoch) * 86400
+		// Julian day REALs carry about millisecond precision.
+		t = time.UnixMilli(int64(math.Round(sec * 1000)))
+	default:
+		if isInt {
+			return fmt.Errorf("cannot scan int64 %d into CustomTime", i)
+		}
+		return fmt.Errorf("cannot scan float64 %v into CustomTime", f)
+	}
+	ct.Time = t
+	ct.Valid = true
+	return nil
+}
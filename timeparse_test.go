@@ -0,0 +1,300 @@
+// timeparse_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCustomTimeScanText(t *testing.T) {
+	paris := time.FixedZone("", 2*3600)
+	tests := []struct {
+		in   interface{}
+		want time.Time
+	}{
+		{"2023-01-01", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{[]byte("2023-01-01"), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2023-06-15T10:20:30.5+02:00", time.Date(2023, 6, 15, 10, 20, 30, 500000000, paris)},
+		{[]byte("2023-06-15 10:20:30.123456+02"), time.Date(2023, 6, 15, 10, 20, 30, 123456000, paris)},
+		{"2023-06-15 10:20:30+02:00", time.Date(2023, 6, 15, 10, 20, 30, 0, paris)},
+		{"2023-06-15 10:20:30", time.Date(2023, 6, 15, 10, 20, 30, 0, time.UTC)},
+		{[]byte("1672531200000"), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		ct := &CustomTime{}
+		if err := ct.Scan(tt.in); err != nil {
+			t.Errorf("Error scanning %s into CustomTime: %v", tt.in, err)
+			continue
+		}
+		if !ct.Valid || !ct.Time.Equal(tt.want) {
+			t.Errorf("Expected Valid true and Time %v for %s, got Valid %v and Time %v", tt.want, tt.in, ct.Valid, ct.Time)
+		}
+	}
+}
+
+func TestRegisterTimeLayout(t *testing.T) {
+	defer func(layouts []string) { extraTimeLayouts = layouts }(extraTimeLayouts)
+
+	ct := &CustomTime{}
+	if err := ct.Scan("15/06/2023"); err == nil {
+		t.Errorf("Expected error when scanning unregistered layout, got nil")
+	}
+
+	RegisterTimeLayout("02/01/2006")
+	if err := ct.Scan("15/06/2023"); err != nil {
+		t.Errorf("Error scanning registered layout: %v", err)
+	}
+	want := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	if !ct.Valid || !ct.Time.Equal(want) {
+		t.Errorf("Expected Valid true and Time %v, got Valid %v and Time %v", want, ct.Valid, ct.Time)
+	}
+
+	ct = &CustomTime{}
+	if err := ct.UnmarshalJSON([]byte(`"15/06/2023"`)); err != nil {
+		t.Errorf("Error unmarshalling registered layout: %v", err)
+	}
+	if !ct.Valid || !ct.Time.Equal(want) {
+		t.Errorf("Expected Valid true and Time %v, got Valid %v and Time %v", want, ct.Valid, ct.Time)
+	}
+	if err := ct.UnmarshalJSON([]byte(`"June 15"`)); err == nil {
+		t.Errorf("Expected error when unmarshalling unregistered layout, got nil")
+	}
+}
+
+func TestCustomTimeScanMySQL(t *testing.T) {
+	ct := &CustomTime{}
+	if err := ct.Scan([]byte("2023-06-15 10:20:30.250000")); err != nil {
+		t.Errorf("Error scanning MySQL DATETIME: %v", err)
+	}
+	want := time.Date(2023, 6, 15, 10, 20, 30, 250000000, time.UTC)
+	if !ct.Valid || !ct.Time.Equal(want) {
+		t.Errorf("Expected Valid true and Time %v, got Valid %v and Time %v", want, ct.Valid, ct.Time)
+	}
+
+	if err := ct.Scan("0000-00-00 00:00:00"); err == nil {
+		t.Errorf("Expected error when scanning zero date without MySQL compat, got nil")
+	}
+
+	SetMySQLCompat(true)
+	defer SetMySQLCompat(false)
+
+	for _, in := range []interface{}{"0000-00-00 00:00:00", []byte("0000-00-00"), "0000-00-00 00:00:00.000000", time.Time{}} {
+		ct = NewCustomTime(time.Now())
+		if err := ct.Scan(in); err != nil {
+			t.Errorf("Error scanning zero date %v: %v", in, err)
+		}
+		if ct.Valid {
+			t.Errorf("Expected Valid false for zero date %v", in)
+		}
+	}
+}
+
+func TestCustomTimeScanSQLite(t *testing.T) {
+	defer SetSQLiteTimeStorage(SQLiteTimeISO8601)
+	want := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ct := &CustomTime{}
+	if err := ct.Scan(int64(1672574400)); err == nil {
+		t.Errorf("Expected error when scanning int64 with ISO8601 storage, got nil")
+	}
+
+	tests := []struct {
+		storage SQLiteTimeStorage
+		in      interface{}
+	}{
+		{SQLiteTimeUnixSeconds, int64(1672574400)},
+		{SQLiteTimeUnixSeconds, float64(1672574400)},
+		{SQLiteTimeUnixMillis, int64(1672574400000)},
+		{SQLiteTimeUnixMillis, float64(1672574400000)},
+		{SQLiteTimeJulianDay, 2459946.0},
+		{SQLiteTimeISO8601, "2023-01-01T12:00:00Z"},
+	}
+	for _, tt := range tests {
+		SetSQLiteTimeStorage(tt.storage)
+		ct := &CustomTime{}
+		if err := ct.Scan(tt.in); err != nil {
+			t.Errorf("Error scanning %v with storage %d: %v", tt.in, tt.storage, err)
+			continue
+		}
+		if !ct.Valid || !ct.Time.Equal(want) {
+			t.Errorf("Expected Time %v for %v with storage %d, got %v", want, tt.in, tt.storage, ct.Time)
+		}
+	}
+
+	SetSQLiteTimeStorage(SQLiteTimeUnixSeconds)
+	if err := ct.Scan(1672574400.5); err != nil {
+		t.Errorf("Error scanning fractional seconds: %v", err)
+	}
+	if ct.Time.Nanosecond() != 500000000 {
+		t.Errorf("Expected 500ms fraction, got %dns", ct.Time.Nanosecond())
+	}
+}
+
+func TestUnixTimestampPolicy(t *testing.T) {
+	defer SetUnixTimestampPolicy(UnixPolicyMillis)
+	sec := time.Unix(1686824430, 0)
+	ms := time.Unix(1686824430, 500000000)
+
+	tests := []struct {
+		policy UnixTimestampPolicy
+		in     string
+		want   time.Time
+	}{
+		{UnixPolicyMillis, "1686824430500", ms},
+		{UnixPolicyMillis, "1686824430", time.UnixMilli(1686824430)},
+		{UnixPolicySeconds, "1686824430", sec},
+		{UnixPolicySeconds, "1686824430.5", ms},
+		{UnixPolicyAuto, "1686824430", sec},
+		{UnixPolicyAuto, "1686824430500", ms},
+		{UnixPolicyAuto, "-1686824430", time.Unix(-1686824430, 0)},
+	}
+	for _, tt := range tests {
+		SetUnixTimestampPolicy(tt.policy)
+		var ct CustomTime
+		if err := ct.UnmarshalJSON([]byte(tt.in)); err != nil {
+			t.Errorf("Error unmarshalling %s: %v", tt.in, err)
+		}
+		if !ct.Time.Equal(tt.want) {
+			t.Errorf("Policy %d: expected %v for %s, got %v", tt.policy, tt.want, tt.in, ct.Time)
+		}
+	}
+
+	SetUnixTimestampPolicy(UnixPolicyAuto)
+	if got := NewCustomTimeInt64(1686824430); !got.Time.Equal(sec) {
+		t.Errorf("Expected %v, got %v", sec, got.Time)
+	}
+	if got := NewCustomTimeFloat64(1686824430500); !got.Time.Equal(ms) {
+		t.Errorf("Expected %v, got %v", ms, got.Time)
+	}
+	var ct CustomTime
+	if err := ct.Scan("1686824430"); err != nil || !ct.Time.Equal(sec) {
+		t.Errorf("Expected %v, got %v and error %v", sec, ct.Time, err)
+	}
+}
+
+func TestCustomTimeHTTPDates(t *testing.T) {
+	want := time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)
+	for _, in := range []string{
+		"Sun, 06 Nov 1994 08:49:37 GMT",
+		"Sun, 06 Nov 1994 08:49:37 +0000",
+		"Sunday, 06-Nov-94 08:49:37 GMT",
+		"Sun Nov  6 08:49:37 1994",
+	} {
+		var ct CustomTime
+		if err := ct.Scan(in); err != nil || !ct.Time.Equal(want) {
+			t.Errorf("Expected %v when scanning %q, got %v and error %v", want, in, ct.Time, err)
+		}
+		ct = CustomTime{}
+		b, _ := json.Marshal(in)
+		if err := ct.UnmarshalJSON(b); err != nil || !ct.Time.Equal(want) {
+			t.Errorf("Expected %v when unmarshalling %s, got %v and error %v", want, b, ct.Time, err)
+		}
+	}
+}
+
+func TestCustomTimeNegativeTimestamps(t *testing.T) {
+	moon := time.Date(1969, 7, 20, 20, 17, 40, 0, time.UTC)
+	ms := moon.UnixMilli()
+	old := time.Date(1901, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{strconv.FormatInt(ms, 10), moon},
+		{strconv.FormatInt(ms, 10) + ".0", moon},
+		{strconv.FormatInt(old.UnixMilli(), 10), old},
+		{`"1901-01-01"`, old},
+		{`{"iso":"1901-01-01T00:00:00Z","unixms":-2177452800000}`, old},
+	}
+	for _, tt := range tests {
+		var ct CustomTime
+		if err := ct.UnmarshalJSON([]byte(tt.in)); err != nil {
+			t.Errorf("Error unmarshalling %s: %v", tt.in, err)
+		}
+		if !ct.Valid || !ct.Time.Equal(tt.want) {
+			t.Errorf("Expected %v for %s, got %v", tt.want, tt.in, ct.Time)
+		}
+	}
+
+	var ct CustomTime
+	if err := ct.Scan(strconv.FormatInt(ms, 10)); err != nil || !ct.Time.Equal(moon) {
+		t.Errorf("Expected %v when scanning negative text, got %v and error %v", moon, ct.Time, err)
+	}
+	if got := NewCustomTimeInt64(ms); !got.Time.Equal(moon) {
+		t.Errorf("Expected %v from NewCustomTimeInt64, got %v", moon, got.Time)
+	}
+
+	b, err := json.Marshal(NewCustomTime(old))
+	if err != nil {
+		t.Fatalf("Error marshalling: %v", err)
+	}
+	ct = CustomTime{}
+	if err := json.Unmarshal(b, &ct); err != nil || !ct.Time.Equal(old) {
+		t.Errorf("Expected %v after round trip of %s, got %v and error %v", old, b, ct.Time, err)
+	}
+}
+
+func TestTimeYearRange(t *testing.T) {
+	var ct CustomTime
+	err := ct.UnmarshalJSON([]byte("99999999999999999"))
+	if !errors.Is(err, ErrTimeOutOfRange) || ct.Valid {
+		t.Errorf("Expected ErrTimeOutOfRange and null, got %v and Valid %v", err, ct.Valid)
+	}
+	if err := ct.Scan(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrTimeOutOfRange) {
+		t.Errorf("Expected ErrTimeOutOfRange when scanning year 10000, got %v", err)
+	}
+
+	SetTimeYearRange(1900, 2100)
+	defer SetTimeYearRange(1, 9999)
+	if err := ct.Scan("1850-01-01"); !errors.Is(err, ErrTimeOutOfRange) {
+		t.Errorf("Expected ErrTimeOutOfRange for 1850, got %v", err)
+	}
+	if err := ct.Scan("2000-01-01"); err != nil || !ct.Valid {
+		t.Errorf("Expected 2000 to be accepted, got %v", err)
+	}
+	if err := ct.Scan(nil); err != nil {
+		t.Errorf("Expected null to be accepted, got %v", err)
+	}
+
+	SetTimeYearRange(0, 0)
+	if err := ct.UnmarshalJSON([]byte("99999999999999999")); err != nil {
+		t.Errorf("Expected no error with the check disabled, got %v", err)
+	}
+}
+
+func TestCustomTimeUnmarshalPartialObject(t *testing.T) {
+	tm := time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`{"unixms":1686824430123}`, tm.Truncate(time.Millisecond)},
+		{`{"unix":1686824430}`, tm.Truncate(time.Second)},
+		{`{"unix":1686824430,"us":123456789}`, tm},
+		{`{"full":"1686824430123456"}`, tm.Truncate(time.Microsecond)},
+		{`{"full":1686824430123456,"unixms":1}`, tm.Truncate(time.Microsecond)},
+		{`{"unixms":1686824430123,"unix":1}`, tm.Truncate(time.Millisecond)},
+		{`{"iso":"2023-06-15T10:20:30.123456789Z","unixms":1}`, tm},
+		{`{"unix":0}`, time.Unix(0, 0)},
+	}
+	for _, tt := range tests {
+		var ct CustomTime
+		if err := ct.UnmarshalJSON([]byte(tt.in)); err != nil {
+			t.Errorf("Error unmarshalling %s: %v", tt.in, err)
+		}
+		if !ct.Valid || !ct.Time.Equal(tt.want) {
+			t.Errorf("Expected %v for %s, got %v", tt.want, tt.in, ct.Time)
+		}
+	}
+
+	for _, in := range []string{`{}`, `{"tz":"UTC"}`, `{"full":"abc"}`} {
+		var ct CustomTime
+		if err := ct.UnmarshalJSON([]byte(in)); err == nil {
+			t.Errorf("Expected error for %s, got nil", in)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// timerelative.go
+package octypes
+
+import (
+	"strconv"
+	"time"
+)
+
+// relativeUnits are the units used by Relative, largest first.
+var relativeUnits = []struct {
+	d    time.Duration
+	name string
+}{
+	{365 * 24 * time.Hour, "year"},
+	{30 * 24 * time.Hour, "month"},
+	{7 * 24 * time.Hour, "week"},
+	{24 * time.Hour, "day"},
+	{time.Hour, "hour"},
+	{time.Minute, "minute"},
+}
+
+// Relative describes ct relative to now in English, e.g. "3 hours ago",
+// "in 2 days" or "just now" for less than a minute. Amounts are truncated
+// to the largest whole unit. A null CustomTime gives an empty string.
+func (ct CustomTime) Relative(now time.Time) string {
+	if !ct.Valid {
+		return ""
+	}
+	d := now.Sub(ct.Time)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	for _, u := range relativeUnits {
+		if d < u.d {
+			continue
+		}
+		n := int64(d / u.d)
+		s := strconv.FormatInt(n, 10) + " " + u.name
+		if n != 1 {
+			s += "s"
+		}
+		if future {
+			return "in " + s
+		}
+		return s + " ago"
+	}
+	return "just now"
+}
+
+// Humanize describes ct relative to the current time. See Relative.
+func (ct CustomTime) Humanize() string {
+	return ct.Relative(nowFunc())
+}
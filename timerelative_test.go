@@ -0,0 +1,63 @@
+// timerelative_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCustomTimeRelative(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-30 * time.Second, "just now"},
+		{-time.Minute, "1 minute ago"},
+		{-3*time.Hour - 20*time.Minute, "3 hours ago"},
+		{2 * 24 * time.Hour, "in 2 days"},
+		{-15 * 24 * time.Hour, "2 weeks ago"},
+		{-800 * 24 * time.Hour, "2 years ago"},
+	}
+	for _, tt := range tests {
+		if got := NewCustomTime(now.Add(tt.d)).Relative(now); got != tt.want {
+			t.Errorf("Expected %q for %v, got %q", tt.want, tt.d, got)
+		}
+	}
+	if got := (CustomTime{}).Relative(now); got != "" {
+		t.Errorf("Expected empty string for null, got %q", got)
+	}
+}
+
+func TestTimeRelativeOutput(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	SetNowFunc(func() time.Time { return now })
+	defer SetNowFunc(nil)
+
+	SetTimeRelativeOutput(true)
+	defer SetTimeRelativeOutput(false)
+	b, _ := json.Marshal(NewCustomTime(now.Add(-3 * time.Hour)))
+	var tr TimeResponse
+	if err := json.Unmarshal(b, &tr); err != nil || tr.Relative != "3 hours ago" {
+		t.Errorf("Expected relative %q, got %s", "3 hours ago", b)
+	}
+}
+
+func TestSetNowFunc(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	SetNowFunc(func() time.Time { return now })
+	defer SetNowFunc(nil)
+
+	if ct := NewCustomTimeNow(); !ct.Valid || !ct.Time.Equal(now) {
+		t.Errorf("Expected %v, got %v", now, ct.Time)
+	}
+	if got := NewCustomTime(now.Add(-time.Hour)).Humanize(); got != "1 hour ago" {
+		t.Errorf("Expected %q, got %q", "1 hour ago", got)
+	}
+
+	SetNowFunc(nil)
+	if ct := NewCustomTimeNow(); ct.Time.Equal(now) {
+		t.Errorf("Expected real clock after reset, got %v", ct.Time)
+	}
+}
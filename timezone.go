@@ -0,0 +1,71 @@
+// timezone.go
+package octypes
+
+import (
+	"errors"
+	"time"
+)
+
+// errInvalidBinaryTime is returned by CustomTime.UnmarshalBinary for
+// malformed input.
+var errInvalidBinaryTime = errors.New("invalid CustomTime binary data")
+
+// preserveLocation moves t into the location named tz when location
+// preservation is enabled. Only the location changes, never the instant;
+// unknown names leave t unchanged.
+func preserveLocation(t time.Time, tz string) time.Time {
+	if !timeZonePreserve || tz == "" {
+		return t
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. Unlike
+// time.Time, the encoding carries the location name, which UnmarshalBinary
+// restores when SetTimeZonePreserve is enabled.
+func (ct CustomTime) MarshalBinary() ([]byte, error) {
+	if !ct.Valid {
+		return []byte{0}, nil
+	}
+	tb, err := ct.Time.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, 2+len(tb)+len(ct.Time.Location().String()))
+	b = append(b, 1, byte(len(tb)))
+	b = append(b, tb...)
+	return append(b, ct.Time.Location().String()...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (ct *CustomTime) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errInvalidBinaryTime
+	}
+	if data[0] == 0 {
+		if len(data) != 1 {
+			return errInvalidBinaryTime
+		}
+		*ct = CustomTime{}
+		return nil
+	}
+	if data[0] != 1 || len(data) < 2 || len(data) < 2+int(data[1]) {
+		return errInvalidBinaryTime
+	}
+	n := 2 + int(data[1])
+	var t time.Time
+	if err := t.UnmarshalBinary(data[2:n]); err != nil {
+		return err
+	}
+	// Converting the location name allocates, so skip it unless it is used.
+	if timeZonePreserve {
+		t = preserveLocation(t, string(data[n:]))
+	}
+	ct.Time = t
+	ct.Valid = true
+	return nil
+}
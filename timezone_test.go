@@ -0,0 +1,93 @@
+// timezone_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeZonePreserveJSON(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Europe/Paris not available: %v", err)
+	}
+	tm := time.Date(2023, 6, 15, 10, 20, 30, 0, paris)
+	b, err := json.Marshal(NewCustomTime(tm))
+	if err != nil {
+		t.Fatalf("Error marshalling: %v", err)
+	}
+
+	var ct CustomTime
+	if err := json.Unmarshal(b, &ct); err != nil {
+		t.Errorf("Error unmarshalling: %v", err)
+	}
+	if ct.Time.Location().String() == "Europe/Paris" {
+		t.Errorf("Expected location not to be preserved by default")
+	}
+
+	SetTimeZonePreserve(true)
+	defer SetTimeZonePreserve(false)
+	if err := json.Unmarshal(b, &ct); err != nil {
+		t.Errorf("Error unmarshalling: %v", err)
+	}
+	if ct.Time.Location().String() != "Europe/Paris" || !ct.Time.Equal(tm) {
+		t.Errorf("Expected %v in Europe/Paris, got %v in %s", tm, ct.Time, ct.Time.Location())
+	}
+
+	if err := json.Unmarshal([]byte(`{"iso":"2023-06-15T10:20:30Z","tz":"Nowhere/Special"}`), &ct); err != nil {
+		t.Errorf("Error unmarshalling unknown zone: %v", err)
+	}
+	if ct.Time.Location().String() != "UTC" {
+		t.Errorf("Expected UTC for unknown zone, got %s", ct.Time.Location())
+	}
+}
+
+func TestCustomTimeBinary(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Europe/Paris not available: %v", err)
+	}
+	SetTimeZonePreserve(true)
+	defer SetTimeZonePreserve(false)
+
+	tm := time.Date(2023, 1, 15, 10, 20, 30, 123, paris)
+	b, err := NewCustomTime(tm).MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error marshalling binary: %v", err)
+	}
+	var ct CustomTime
+	if err := ct.UnmarshalBinary(b); err != nil {
+		t.Errorf("Error unmarshalling binary: %v", err)
+	}
+	if !ct.Valid || !ct.Time.Equal(tm) || ct.Time.Location().String() != "Europe/Paris" {
+		t.Errorf("Expected %v in Europe/Paris, got %v in %s", tm, ct.Time, ct.Time.Location())
+	}
+
+	b, _ = NewCustomTimeNull().MarshalBinary()
+	if err := ct.UnmarshalBinary(b); err != nil || ct.Valid {
+		t.Errorf("Expected null after round trip, got Valid %v and error %v", ct.Valid, err)
+	}
+
+	for _, in := range [][]byte{nil, {2}, {1, 15, 1}, {0, 0}} {
+		if err := ct.UnmarshalBinary(in); err == nil {
+			t.Errorf("Expected error for %v, got nil", in)
+		}
+	}
+}
+
+func TestCustomTimeUnmarshalBinaryAllocs(t *testing.T) {
+	data, err := NewCustomTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)).MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var ct CustomTime
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := ct.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,131 @@
+// tomap.go
+package octypes
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ToMap converts the `db` tagged fields of v, a struct or a pointer to one,
+// to a map keyed by column name, for templating engines and audit-log
+// diffs. octypes values are unwrapped to their Go value, or nil when null:
+// a NullString becomes a string, a CustomTime a time.Time, a LocalizedText
+// a map[string]string, and so on. Other fields are stored as is.
+func ToMap(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot convert %T to a map: not a struct", v)
+	}
+	fields := dbFields(rv.Type())
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.name] = unwrapValue(rv.FieldByIndex(f.index).Interface())
+	}
+	return m, nil
+}
+
+// unwrapValue returns the Go value held by an octypes value, or nil if it
+// is null.
+func unwrapValue(v interface{}) interface{} {
+	if n, ok := v.(Nullable); ok && n.IsNull() {
+		return nil
+	}
+	switch v := v.(type) {
+	case NullString:
+		return v.String
+	case NullInt64:
+		return v.Int64
+	case NullBool:
+		return v.Bool
+	case NullFloat64:
+		return v.Float64
+	case CustomTime:
+		return v.Time
+	case NullBytes:
+		return v.Bytes
+	case LocalizedText:
+		return map[string]string(v)
+	case LocalizedTextHstore:
+		return map[string]string(v)
+	case IntDictionary:
+		return map[string]int(v)
+	case PluralizedText:
+		return map[string]map[PluralCategory]string(v)
+	case LocalizedContent:
+		return map[string]Content(v)
+	case NullStringArray:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = unwrapValue(e)
+		}
+		return out
+	case NullInt64Array:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = unwrapValue(e)
+		}
+		return out
+	}
+	return v
+}
+
+// FromMap sets the `db` tagged fields of the struct dst points to from the
+// entries of m with the same column name; fields without an entry are left
+// unchanged. A nil entry sets the field to its zero value, which is null
+// for octypes values. Other entries are assigned directly when their type
+// allows it, passed to the field's Scan method, or as a last resort
+// converted through JSON, so maps decoded from JSON documents are accepted.
+func FromMap(m map[string]interface{}, dst interface{}) error {
+	rv, err := structPointerValue(dst)
+	if err != nil {
+		return err
+	}
+	for _, f := range dbFields(rv.Type()) {
+		v, ok := m[f.name]
+		if !ok {
+			continue
+		}
+		if err := setFromMap(rv.FieldByIndex(f.index), v); err != nil {
+			return fmt.Errorf("column %q: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// setFromMap sets field to v as described by FromMap.
+func setFromMap(field reflect.Value, v interface{}) error {
+	if v == nil {
+		field.SetZero()
+		return nil
+	}
+	src := reflect.ValueOf(v)
+	if src.Type().AssignableTo(field.Type()) {
+		field.Set(src)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Map, reflect.Slice:
+		if src.Kind() == field.Kind() && src.Type().ConvertibleTo(field.Type()) {
+			field.Set(src.Convert(field.Type()))
+			return nil
+		}
+	}
+	if s, ok := field.Addr().Interface().(sql.Scanner); ok {
+		if err := s.Scan(v); err == nil {
+			return nil
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, field.Addr().Interface()); err != nil {
+		return fmt.Errorf("cannot set %s from %T: %w", field.Type(), v, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// tomap_test.go
+package octypes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mapRecord struct {
+	ID      int64           `db:"id"`
+	Name    NullString      `db:"name"`
+	Age     NullInt64       `db:"age"`
+	Title   LocalizedText   `db:"title"`
+	Created CustomTime      `db:"created_at"`
+	Tags    NullStringArray `db:"tags"`
+	Skipped string
+}
+
+func TestToMap(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := mapRecord{
+		ID:      1,
+		Name:    *NewNullString("Ann"),
+		Title:   LocalizedText{"en": "Hi"},
+		Created: *NewCustomTime(created),
+		Tags:    NullStringArray{*NewNullString("a"), {}},
+	}
+	m, err := ToMap(&r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"id":         int64(1),
+		"name":       "Ann",
+		"age":        nil,
+		"title":      map[string]string{"en": "Hi"},
+		"created_at": created,
+		"tags":       []interface{}{"a", nil},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("Expected %v, got %v", want, m)
+	}
+
+	if _, err := ToMap(42); err == nil {
+		t.Errorf("Expected error for non-struct")
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	r := mapRecord{Age: *NewNullInt64(3), Skipped: "kept"}
+	err := FromMap(map[string]interface{}{
+		"id":         int64(7),
+		"name":       "Bob",
+		"age":        nil,
+		"title":      map[string]interface{}{"fr": "Salut"},
+		"created_at": "2024-01-02T03:04:05Z",
+		"tags":       []interface{}{"x", nil},
+	}, &r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.ID != 7 || r.Name.String != "Bob" || r.Age.Valid || r.Title["fr"] != "Salut" || r.Skipped != "kept" {
+		t.Errorf("Unexpected record %+v", r)
+	}
+	if !r.Created.Valid || r.Created.Time.Year() != 2024 {
+		t.Errorf("Expected created time, got %v", r.Created)
+	}
+	if len(r.Tags) != 2 || r.Tags[0].String != "x" || r.Tags[1].Valid {
+		t.Errorf("Expected tags [x, null], got %v", r.Tags)
+	}
+
+	m, _ := ToMap(r)
+	var back mapRecord
+	if err := FromMap(m, &back); err != nil || !back.Title.Equal(r.Title) || !back.Tags.Equal(r.Tags) {
+		t.Errorf("Expected round trip, got %+v and %v", back, err)
+	}
+
+	if err := FromMap(map[string]interface{}{"age": "old"}, &r); err == nil {
+		t.Errorf("Expected error for invalid value")
+	}
+	if err := FromMap(nil, r); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+}
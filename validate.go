@@ -0,0 +1,420 @@
+// validate.go
+package octypes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Errors wrapped by FieldError.
+var (
+	ErrRequired        = errors.New("is required")
+	ErrBelowMin        = errors.New("is below the minimum")
+	ErrAboveMax        = errors.New("is above the maximum")
+	ErrTooShort        = errors.New("is too short")
+	ErrTooLong         = errors.New("is too long")
+	ErrPatternMismatch = errors.New("does not match the pattern")
+)
+
+// FieldError reports a struct field that failed a constraint.
+type FieldError struct {
+	// Field is the field path, e.g. "Address.City".
+	Field string
+	// Rule is the failed rule as written in a tag, e.g. "max=100".
+	Rule string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %v (%s)", e.Field, e.Err, e.Rule)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors lists every constraint failed by a struct.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the FieldErrors, so errors.Is(err, ErrRequired) reports
+// whether any field is missing.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// Constraint is the set of rules checked on one field. Build one from
+// Rule values or a `validate` tag.
+type Constraint struct {
+	required       bool
+	min, max       *float64
+	minLen, maxLen int
+	pattern        *regexp.Regexp
+}
+
+// Rule adds a check to a Constraint.
+type Rule func(c *Constraint)
+
+// Required rejects null values. Without it, null values pass every other
+// rule, so optional fields need no special casing.
+func Required() Rule {
+	return func(c *Constraint) { c.required = true }
+}
+
+// Min rejects numbers below min.
+func Min(min float64) Rule {
+	return func(c *Constraint) { c.min = &min }
+}
+
+// Max rejects numbers above max.
+func Max(max float64) Rule {
+	return func(c *Constraint) { c.max = &max }
+}
+
+// MinLen rejects strings shorter than n runes, or byte slices shorter than
+// n bytes.
+func MinLen(n int) Rule {
+	return func(c *Constraint) { c.minLen = n }
+}
+
+// MaxLen rejects strings longer than n runes, or byte slices longer than n
+// bytes.
+func MaxLen(n int) Rule {
+	return func(c *Constraint) { c.maxLen = n }
+}
+
+// Pattern rejects strings not matching re.
+func Pattern(re *regexp.Regexp) Rule {
+	return func(c *Constraint) { c.pattern = re }
+}
+
+// Validator checks structs against constraints declared with `validate`
+// tags and with Field. The zero value and NewValidator only use tags.
+//
+// Rules apply to the values held by octypes fields: numbers for NullInt64
+// and NullFloat64, strings for NullString, and each entry of map and array
+// types, so MaxLen on a LocalizedText limits every translation. Plain Go
+// strings and numbers are supported as well and are never null.
+type Validator struct {
+	fields map[string]*Constraint
+}
+
+// NewValidator returns a Validator without field rules.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Field sets the constraint of the field at path, e.g. "Name" or
+// "Address.City", replacing its `validate` tag.
+func (v *Validator) Field(path string, rules ...Rule) *Validator {
+	c := &Constraint{}
+	for _, r := range rules {
+		r(c)
+	}
+	if v.fields == nil {
+		v.fields = make(map[string]*Constraint)
+	}
+	v.fields[path] = c
+	return v
+}
+
+// Validate checks the struct s, or the struct it points to, and returns
+// ValidationErrors listing every failure, or nil.
+func (v *Validator) Validate(s interface{}) error {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot validate %T: not a struct", s)
+	}
+	var errs ValidationErrors
+	if err := v.validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks s against its `validate` tags, such as
+//
+//	Name NullString `validate:"required,maxlen=50"`
+//	Age  NullInt64  `validate:"min=0,max=150"`
+//	Code NullString `validate:"regexp=^[A-Z]{3}$"`
+//
+// The regexp rule takes the rest of the tag, so it must come last.
+func Validate(s interface{}) error {
+	return (*Validator)(nil).Validate(s)
+}
+
+func (v *Validator) validateStruct(rv reflect.Value, prefix string, errs *ValidationErrors) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		path := prefix + f.Name
+		c, err := v.constraint(f, path)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+		fv := rv.Field(i)
+		if c != nil {
+			if err := c.check(fv, path, errs); err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && !isScanner(fv.Type()) {
+			if err := v.validateStruct(fv, path+".", errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// constraint returns the constraint of field f, or nil if it has none.
+func (v *Validator) constraint(f reflect.StructField, path string) (*Constraint, error) {
+	if v != nil {
+		if c, ok := v.fields[path]; ok {
+			return c, nil
+		}
+	}
+	tag, ok := f.Tag.Lookup("validate")
+	if !ok {
+		return nil, nil
+	}
+	return parseValidateTag(tag)
+}
+
+var validateTagCache sync.Map // map[string]*Constraint
+
+// parseValidateTag parses a `validate` tag.
+func parseValidateTag(tag string) (*Constraint, error) {
+	if c, ok := validateTagCache.Load(tag); ok {
+		return c.(*Constraint), nil
+	}
+	c := &Constraint{}
+	for rest := tag; rest != ""; {
+		var rule string
+		if strings.HasPrefix(rest, "regexp=") {
+			rule, rest = rest, ""
+		} else {
+			rule, rest, _ = strings.Cut(rest, ",")
+		}
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "required":
+			c.required = true
+		case "min", "max":
+			f, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule %q", rule)
+			}
+			if name == "min" {
+				c.min = &f
+			} else {
+				c.max = &f
+			}
+		case "minlen", "maxlen":
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid rule %q", rule)
+			}
+			if name == "minlen" {
+				c.minLen = n
+			} else {
+				c.maxLen = n
+			}
+		case "regexp":
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule %q: %w", rule, err)
+			}
+			c.pattern = re
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown rule %q", rule)
+		}
+	}
+	validateTagCache.Store(tag, c)
+	return c, nil
+}
+
+// check appends the failures of fv to errs. It returns an error if a rule
+// does not apply to the field's type.
+func (c *Constraint) check(fv reflect.Value, path string, errs *ValidationErrors) error {
+	fail := func(rule string, err error) {
+		*errs = append(*errs, &FieldError{Field: path, Rule: rule, Err: err})
+	}
+	vals, err := validationValues(fv)
+	if err != nil {
+		return err
+	}
+	if (c.min != nil || c.max != nil) && vals.kind != kindNumber {
+		return fmt.Errorf("min and max do not apply to %s", fv.Type())
+	}
+	if (c.minLen > 0 || c.maxLen > 0) && vals.kind != kindString && vals.kind != kindBytes {
+		return fmt.Errorf("length rules do not apply to %s", fv.Type())
+	}
+	if c.pattern != nil && vals.kind != kindString {
+		return fmt.Errorf("regexp does not apply to %s", fv.Type())
+	}
+	if vals.null {
+		if c.required {
+			fail("required", ErrRequired)
+		}
+		return nil
+	}
+	for _, n := range vals.nums {
+		if c.min != nil && n < *c.min {
+			fail("min="+strconv.FormatFloat(*c.min, 'g', -1, 64), ErrBelowMin)
+			break
+		}
+		if c.max != nil && n > *c.max {
+			fail("max="+strconv.FormatFloat(*c.max, 'g', -1, 64), ErrAboveMax)
+			break
+		}
+	}
+	for _, s := range vals.strs {
+		if failed := c.checkLen(utf8.RuneCountInString(s), fail); failed {
+			break
+		}
+		if c.pattern != nil && !c.pattern.MatchString(s) {
+			fail("regexp="+c.pattern.String(), ErrPatternMismatch)
+			break
+		}
+	}
+	if vals.kind == kindBytes {
+		c.checkLen(len(vals.bytes), fail)
+	}
+	return nil
+}
+
+// checkLen checks a length against minLen and maxLen and reports whether
+// it failed.
+func (c *Constraint) checkLen(n int, fail func(string, error)) bool {
+	if c.minLen > 0 && n < c.minLen {
+		fail("minlen="+strconv.Itoa(c.minLen), ErrTooShort)
+		return true
+	}
+	if c.maxLen > 0 && n > c.maxLen {
+		fail("maxlen="+strconv.Itoa(c.maxLen), ErrTooLong)
+		return true
+	}
+	return false
+}
+
+// valueKind is the kind of values a field holds for validation.
+type valueKind uint8
+
+const (
+	kindOther valueKind = iota
+	kindString
+	kindNumber
+	kindBytes
+)
+
+// fieldValues are the values of a field that rules check.
+type fieldValues struct {
+	kind  valueKind
+	null  bool
+	strs  []string
+	nums  []float64
+	bytes []byte
+}
+
+// validationValues extracts the values rules check from fv. Pointers are
+// classified by the type they point to, and a nil pointer is null.
+func validationValues(fv reflect.Value) (fieldValues, error) {
+	if fv.Kind() == reflect.Pointer {
+		if !fv.IsNil() {
+			return validationValues(fv.Elem())
+		}
+		vals, err := validationValues(reflect.Zero(fv.Type().Elem()))
+		vals.null = true
+		return vals, err
+	}
+	var vals fieldValues
+	switch v := fv.Interface().(type) {
+	case NullString:
+		vals = fieldValues{kind: kindString, strs: []string{v.String}}
+	case NullInt64:
+		vals = fieldValues{kind: kindNumber, nums: []float64{float64(v.Int64)}}
+	case NullFloat64:
+		vals = fieldValues{kind: kindNumber, nums: []float64{v.Float64}}
+	case NullBytes:
+		vals = fieldValues{kind: kindBytes, bytes: v.Bytes}
+	case LocalizedText:
+		vals = fieldValues{kind: kindString, strs: mapValues(v)}
+	case LocalizedTextHstore:
+		vals = fieldValues{kind: kindString, strs: mapValues(v)}
+	case IntDictionary:
+		vals.kind = kindNumber
+		for _, n := range v {
+			vals.nums = append(vals.nums, float64(n))
+		}
+	case NullStringArray:
+		vals.kind = kindString
+		for _, e := range v {
+			if e.Valid {
+				vals.strs = append(vals.strs, e.String)
+			}
+		}
+	case NullInt64Array:
+		vals.kind = kindNumber
+		for _, e := range v {
+			if e.Valid {
+				vals.nums = append(vals.nums, float64(e.Int64))
+			}
+		}
+	case Nullable:
+	default:
+		switch fv.Kind() {
+		case reflect.String:
+			return fieldValues{kind: kindString, strs: []string{fv.String()}}, nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return fieldValues{kind: kindNumber, nums: []float64{float64(fv.Int())}}, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return fieldValues{kind: kindNumber, nums: []float64{float64(fv.Uint())}}, nil
+		case reflect.Float32, reflect.Float64:
+			return fieldValues{kind: kindNumber, nums: []float64{fv.Float()}}, nil
+		}
+		return fieldValues{}, fmt.Errorf("cannot validate %s", fv.Type())
+	}
+	vals.null = fv.Interface().(Nullable).IsNull()
+	return vals, nil
+}
+
+// mapValues returns the values of m.
+func mapValues[M ~map[string]string](m M) []string {
+	strs := make([]string, 0, len(m))
+	for _, s := range m {
+		strs = append(strs, s)
+	}
+	return strs
+}
@@ -0,0 +1,140 @@
+// validate_test.go
+package octypes
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+type validateAddress struct {
+	City NullString `validate:"required"`
+}
+
+type validateUser struct {
+	Name    NullString      `validate:"required,maxlen=5"`
+	Age     NullInt64       `validate:"min=0,max=150"`
+	Code    NullString      `validate:"regexp=^[A-Z]{2,3}$"`
+	Title   LocalizedText   `validate:"minlen=2"`
+	Active  NullBool        `validate:"required"`
+	Score   float64         `validate:"max=10"`
+	Address validateAddress `db:"-"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := validateUser{
+		Name:    *NewNullString("Ann"),
+		Code:    *NewNullString("ABC"),
+		Active:  *NewNullBool(false),
+		Address: validateAddress{City: *NewNullString("Paris")},
+	}
+	if err := Validate(&valid); err != nil {
+		t.Errorf("Expected valid user, got %v", err)
+	}
+
+	invalid := validateUser{
+		Name:  *NewNullString("Annabelle"),
+		Age:   *NewNullInt64(-1),
+		Code:  *NewNullString("abc,d"),
+		Title: LocalizedText{"en": "Hi", "fr": "x"},
+		Score: 11,
+	}
+	err := Validate(invalid)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	want := []struct {
+		field string
+		err   error
+	}{
+		{"Name", ErrTooLong},
+		{"Age", ErrBelowMin},
+		{"Code", ErrPatternMismatch},
+		{"Title", ErrTooShort},
+		{"Active", ErrRequired},
+		{"Score", ErrAboveMax},
+		{"Address.City", ErrRequired},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if errs[i].Field != w.field || !errors.Is(errs[i], w.err) {
+			t.Errorf("Expected %s %v, got %v", w.field, w.err, errs[i])
+		}
+	}
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("Expected errors.Is to find ErrRequired")
+	}
+}
+
+func TestValidatorBuilder(t *testing.T) {
+	v := NewValidator().
+		Field("Name", Required(), MinLen(4)).
+		Field("Code", Pattern(regexp.MustCompile(`^\d+$`))).
+		Field("Address.City", MaxLen(3))
+	u := validateUser{
+		Name:    *NewNullString("Ann"),
+		Code:    *NewNullString("12"),
+		Active:  *NewNullBool(true),
+		Address: validateAddress{City: *NewNullString("Paris")},
+	}
+	var errs ValidationErrors
+	if err := v.Validate(u); !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", err)
+	}
+	if errs[0].Field != "Name" || errs[0].Rule != "minlen=4" || errs[1].Field != "Address.City" {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+}
+
+func TestValidateInvalidRules(t *testing.T) {
+	var unknown struct {
+		S NullString `validate:"sometimes"`
+	}
+	if err := Validate(unknown); err == nil {
+		t.Errorf("Expected error for unknown rule")
+	}
+	var mismatch struct {
+		B NullBool `validate:"min=1"`
+	}
+	if err := Validate(mismatch); err == nil {
+		t.Errorf("Expected error for min on NullBool")
+	}
+	if err := Validate(3); err == nil {
+		t.Errorf("Expected error for non-struct")
+	}
+}
+
+func TestValidatePointerFields(t *testing.T) {
+	type form struct {
+		Name *NullString `validate:"required,maxlen=5"`
+		Age  *NullInt64  `validate:"min=18"`
+		Nick *string     `validate:"minlen=2"`
+	}
+	err := Validate(&form{})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "Name" || !errors.Is(errs[0], ErrRequired) {
+		t.Errorf("Expected Name to be required, got %v", err)
+	}
+
+	nick := "x"
+	err = Validate(&form{Name: NewNullString("Alexander"), Age: NewNullInt64(12), Nick: &nick})
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	for i, want := range []error{ErrTooLong, ErrBelowMin, ErrTooShort} {
+		if !errors.Is(errs[i], want) {
+			t.Errorf("Expected %v, got %v", want, errs[i])
+		}
+	}
+
+	nick = "xy"
+	if err := Validate(&form{Name: NewNullString("Alex"), Age: NewNullInt64(30), Nick: &nick}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := Validate(&form{Name: &NullString{}}); !errors.As(err, &errs) || !errors.Is(errs[0], ErrRequired) {
+		t.Errorf("Expected a null NullString to be required, got %v", err)
+	}
+}
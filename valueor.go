@@ -0,0 +1,60 @@
+// valueor.go
+package octypes
+
+import "time"
+
+// ValueOr returns the string, or def if ns is null.
+func (ns NullString) ValueOr(def string) string {
+	if !ns.Valid {
+		return def
+	}
+	return ns.String
+}
+
+// ValueOr returns the integer, or def if ni is null.
+func (ni NullInt64) ValueOr(def int64) int64 {
+	if !ni.Valid {
+		return def
+	}
+	return ni.Int64
+}
+
+// ValueOr returns the boolean, or def if nb is null.
+func (nb NullBool) ValueOr(def bool) bool {
+	if !nb.Valid {
+		return def
+	}
+	return nb.Bool
+}
+
+// ValueOr returns the float, or def if nf is null.
+func (nf NullFloat64) ValueOr(def float64) float64 {
+	if !nf.Valid {
+		return def
+	}
+	return nf.Float64
+}
+
+// ValueOr returns the time, or def if ct is null.
+func (ct CustomTime) ValueOr(def time.Time) time.Time {
+	if !ct.Valid {
+		return def
+	}
+	return ct.Time
+}
+
+// ValueOr returns the byte slice, or def if nb is null.
+func (nb NullBytes) ValueOr(def []byte) []byte {
+	if !nb.Valid {
+		return def
+	}
+	return nb.Bytes
+}
+
+// ValueOr returns the value, or def if c is null.
+func (c Composite[T]) ValueOr(def T) T {
+	if !c.Valid {
+		return def
+	}
+	return c.V
+}
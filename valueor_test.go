@@ -0,0 +1,38 @@
+// valueor_test.go
+package octypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValueOr(t *testing.T) {
+	if got := (NullString{}).ValueOr("n/a"); got != "n/a" {
+		t.Errorf("Expected n/a, got %q", got)
+	}
+	if got := NewNullString("x").ValueOr("n/a"); got != "x" {
+		t.Errorf("Expected x, got %q", got)
+	}
+	if got := (NullInt64{}).ValueOr(-1); got != -1 {
+		t.Errorf("Expected -1, got %d", got)
+	}
+	if got := NewNullInt64(0).ValueOr(-1); got != 0 {
+		t.Errorf("Expected 0, got %d", got)
+	}
+	if got := (NullBool{}).ValueOr(true); !got {
+		t.Errorf("Expected true, got %v", got)
+	}
+	if got := NewNullFloat64(2.5).ValueOr(0); got != 2.5 {
+		t.Errorf("Expected 2.5, got %v", got)
+	}
+	def := time.Unix(0, 0)
+	if got := NewCustomTimeNull().ValueOr(def); !got.Equal(def) {
+		t.Errorf("Expected %v, got %v", def, got)
+	}
+	if got := (NullBytes{}).ValueOr([]byte("d")); string(got) != "d" {
+		t.Errorf("Expected d, got %q", got)
+	}
+	if got := NewComposite(3).ValueOr(0); got != 3 {
+		t.Errorf("Expected 3, got %d", got)
+	}
+}
@@ -0,0 +1,30 @@
+// zerocopy.go
+package octypes
+
+import (
+	"unicode/utf8"
+	"unsafe"
+)
+
+// jsonPlainString returns the contents of the JSON string literal b if it
+// needs no unescaping: it has no backslash escapes and is valid UTF-8, so
+// its bytes are the decoded string. With SetZeroCopyStrings enabled the
+// result aliases b instead of copying it.
+func jsonPlainString(b []byte) (string, bool) {
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return "", false
+	}
+	b = b[1 : len(b)-1]
+	for _, c := range b {
+		if c == '\\' || c == '"' || c < 0x20 {
+			return "", false
+		}
+	}
+	if !utf8.Valid(b) {
+		return "", false
+	}
+	if zeroCopyStrings && len(b) > 0 {
+		return unsafe.String(&b[0], len(b)), true
+	}
+	return string(b), true
+}
@@ -0,0 +1,79 @@
+// zerocopy_test.go
+package octypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestNullStringUnmarshalJSONPlain(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`"hello"`, "hello"},
+		{`""`, ""},
+		{`"café"`, "café"},
+		{`"a\"b"`, `a"b`},
+		{"\"\xff\"", "�"},
+	}
+	for _, tt := range tests {
+		var ns NullString
+		if err := json.Unmarshal([]byte(tt.in), &ns); err != nil {
+			t.Errorf("Unexpected error for %s: %v", tt.in, err)
+			continue
+		}
+		if !ns.Valid || ns.String != tt.want {
+			t.Errorf("Expected %q, got %#v", tt.want, ns)
+		}
+	}
+	var ns NullString
+	if err := ns.UnmarshalJSON([]byte(`"a"b"`)); err == nil {
+		t.Errorf("Expected an error for an invalid literal")
+	}
+}
+
+func TestSetZeroCopyStrings(t *testing.T) {
+	defer SetZeroCopyStrings(false)
+
+	data := []byte(`{"name":"hello"}`)
+	var v struct{ Name NullString }
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if unsafe.StringData(v.Name.String) == &data[9] {
+		t.Errorf("Expected a copy when zero-copy is off")
+	}
+
+	SetZeroCopyStrings(true)
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if unsafe.StringData(v.Name.String) != &data[9] {
+		t.Errorf("Expected the string to alias the input")
+	}
+	if err := json.Unmarshal([]byte(`{"name":"a\nb"}`), &v); err != nil || v.Name.String != "a\nb" {
+		t.Errorf("Expected escaped strings to be decoded, got %q, %v", v.Name.String, err)
+	}
+}
+
+func benchmarkNullStringUnmarshal(b *testing.B, zeroCopy bool) {
+	defer SetZeroCopyStrings(false)
+	SetZeroCopyStrings(zeroCopy)
+	data := []byte(`"` + strings.Repeat("lorem ipsum ", 1000) + `"`)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	var ns NullString
+	for i := 0; i < b.N; i++ {
+		if err := ns.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNullStringUnmarshalJSON(b *testing.B) {
+	b.Run("copy", func(b *testing.B) { benchmarkNullStringUnmarshal(b, false) })
+	b.Run("zerocopy", func(b *testing.B) { benchmarkNullStringUnmarshal(b, true) })
+}